@@ -27,11 +27,18 @@ type Server interface {
 	Type() string
 	// SetStateManager is impl by ServerImplBase to inject StateManager to this actor instance
 	SetStateManager(StateManager)
+	// SetScheduler is impl by ServerImplBase to inject Scheduler to this actor instance
+	SetScheduler(Scheduler)
 	// SaveState is impl by ServerImplBase, It saves the state cache of this actor instance to state store component by calling api of daprd.
 	// Save state is called at two places: 1. On invocation of this actor instance. 2. When new actor starts.
 	SaveState() error
+	// DiscardState is impl by ServerImplBase, It drops the state changes of this actor instance which are not saved.
+	// It is called when the invocation of this actor instance fails, so the changes of a failed invocation are not saved.
+	DiscardState()
 }
 
+// ReminderCallee is impl by actors receiving reminders with their raw parameters.
+// Deprecated: impl ReminderHandler instead, which receives the due time and period as time.Duration.
 type ReminderCallee interface {
 	ReminderCall(string, []byte, string, string)
 }
@@ -40,6 +47,7 @@ type Factory func() Server
 
 type ServerImplBase struct {
 	stateManager StateManager
+	scheduler    Scheduler
 	once         sync.Once
 	id           string
 }
@@ -71,11 +79,26 @@ func (b *ServerImplBase) SaveState() error {
 	return nil
 }
 
+// DiscardState is to drop the state changes of this actor instance which are not saved.
+func (b *ServerImplBase) DiscardState() {
+	if b.stateManager != nil {
+		b.stateManager.Discard()
+	}
+}
+
 type StateManager interface {
 	// Add is to add new state store with @stateName and @value
 	Add(stateName string, value interface{}) error
 	// Get is to get state store of @stateName with type @reply
 	Get(stateName string, reply interface{}) error
+	// TryGet is to get state store of @stateName with type @reply, found is false if it doesn't exist
+	TryGet(stateName string, reply interface{}) (found bool, err error)
+	// GetOrDefault is to get state store of @stateName with type @reply, or @defaultValue if it doesn't exist
+	GetOrDefault(stateName string, reply interface{}, defaultValue interface{}) error
+	// AddOrUpdate is to add state store with @stateName and @addValue, or update it with the value returned by @update
+	AddOrUpdate(stateName string, addValue interface{}, update func(oldValue interface{}) (interface{}, error)) error
+	// GetStateNames is to get the names of all state stores of this actor
+	GetStateNames() ([]string, error)
 	// Set is to set new state store with @stateName and @value
 	Set(stateName string, value interface{}) error
 	// Remove is to remove state store with @stateName
@@ -86,4 +109,6 @@ type StateManager interface {
 	Save() error
 	// Flush is called by stateManager after Save
 	Flush()
+	// Discard is to drop the state changes which are not saved, it's called when the invocation fails
+	Discard()
 }
@@ -1,9 +1,29 @@
 package api
 
 type ActorRuntimeConfig struct {
-	RegisteredActorTypes   []string `json:"entities"`
-	ActorIdleTimeout       string   `json:"actorIdleTimeout"`
-	ActorScanInterval      string   `json:"actorScanInterval"`
-	DrainOngingCallTimeout string   `json:"drainOngoingCallTimeout"`
-	DrainBalancedActors    bool     `json:"drainRebalancedActors"`
+	RegisteredActorTypes       []string               `json:"entities"`
+	ActorIdleTimeout           string                 `json:"actorIdleTimeout,omitempty"`
+	ActorScanInterval          string                 `json:"actorScanInterval,omitempty"`
+	DrainOngingCallTimeout     string                 `json:"drainOngoingCallTimeout,omitempty"`
+	DrainBalancedActors        bool                   `json:"drainRebalancedActors"`
+	Reentrancy                 *ActorReentrancyConfig `json:"reentrancy,omitempty"`
+	RemindersStoragePartitions int                    `json:"remindersStoragePartitions,omitempty"`
+	EntitiesConfig             []*ActorEntityConfig   `json:"entitiesConfig,omitempty"`
+}
+
+// ActorReentrancyConfig is the reentrancy configuration of actors.
+type ActorReentrancyConfig struct {
+	Enabled       bool `json:"enabled"`
+	MaxStackDepth *int `json:"maxStackDepth,omitempty"`
+}
+
+// ActorEntityConfig overrides the runtime level configuration for the actor types of Entities.
+type ActorEntityConfig struct {
+	Entities                   []string               `json:"entities"`
+	ActorIdleTimeout           string                 `json:"actorIdleTimeout,omitempty"`
+	ActorScanInterval          string                 `json:"actorScanInterval,omitempty"`
+	DrainOngoingCallTimeout    string                 `json:"drainOngoingCallTimeout,omitempty"`
+	DrainRebalancedActors      bool                   `json:"drainRebalancedActors,omitempty"`
+	Reentrancy                 *ActorReentrancyConfig `json:"reentrancy,omitempty"`
+	RemindersStoragePartitions int                    `json:"remindersStoragePartitions,omitempty"`
 }
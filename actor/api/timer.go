@@ -5,4 +5,5 @@ type ActorTimerParam struct {
 	Data     []byte `json:"data"`
 	DueTime  string `json:"dueTime"`
 	Period   string `json:"period"`
+	TTL      string `json:"ttl,omitempty"`
 }
@@ -0,0 +1,56 @@
+package codec
+
+import perrors "github.com/pkg/errors"
+
+// ArgsUnmarshaler is implemented by codecs which can split an encoded argument list and decode
+// each element directly into its typed value, without an intermediate generic decoding.
+type ArgsUnmarshaler interface {
+	UnmarshalArgs(data []byte, args []interface{}) error
+}
+
+// MarshalArgs encodes the actor method arguments @args positionally with @c.
+// No argument is encoded as empty data, a single argument is encoded as it is, which keeps the
+// payload compatible with callers from other Dapr SDKs, and more arguments are encoded as a list.
+func MarshalArgs(c Codec, args []interface{}) ([]byte, error) {
+	switch len(args) {
+	case 0:
+		return nil, nil
+	case 1:
+		return c.Marshal(args[0])
+	default:
+		return c.Marshal(args)
+	}
+}
+
+// UnmarshalArgs decodes @data encoded by MarshalArgs into @args, which must be pointers to the
+// typed argument values in the order of the actor method signature.
+func UnmarshalArgs(c Codec, data []byte, args []interface{}) error {
+	switch len(args) {
+	case 0:
+		return nil
+	case 1:
+		return c.Unmarshal(data, args[0])
+	}
+	if u, ok := c.(ArgsUnmarshaler); ok {
+		return u.UnmarshalArgs(data, args)
+	}
+
+	// fallback for codecs without native list splitting, decode generically and re-encode each element.
+	elems := make([]interface{}, 0, len(args))
+	if err := c.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+	if len(elems) != len(args) {
+		return perrors.Errorf("expected %d arguments, got %d", len(args), len(elems))
+	}
+	for i, elem := range elems {
+		elemData, err := c.Marshal(elem)
+		if err != nil {
+			return perrors.Wrapf(err, "failed to encode argument %d", i)
+		}
+		if err := c.Unmarshal(elemData, args[i]); err != nil {
+			return perrors.Wrapf(err, "failed to decode argument %d", i)
+		}
+	}
+	return nil
+}
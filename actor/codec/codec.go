@@ -1,29 +1,55 @@
 package codec
 
-import perrors "github.com/pkg/errors"
+import (
+	"sort"
+	"sync"
+
+	perrors "github.com/pkg/errors"
+)
 
 // Codec is serializer interface.
 type Codec interface {
 	Marshal(interface{}) ([]byte, error)
 	Unmarshal([]byte, interface{}) error
+	// ContentType returns the media type of the data encoded by the codec, such as application/json.
+	ContentType() string
 }
 
 // Factory is factory of codec.
 type Factory func() Codec
 
-// codecFactoryMap stores.
-var codecFactoryMap = make(map[string]Factory)
+var (
+	// codecFactoryMap stores the codec factories by name, it's guarded by codecFactoryLock.
+	codecFactoryMap  = make(map[string]Factory)
+	codecFactoryLock sync.RWMutex
+)
 
 // SetActorCodec set Actor's Codec.
 func SetActorCodec(name string, f Factory) {
+	codecFactoryLock.Lock()
+	defer codecFactoryLock.Unlock()
 	codecFactoryMap[name] = f
 }
 
 // GetActorCodec gets the target codec instance.
 func GetActorCodec(name string) (Codec, error) {
+	codecFactoryLock.RLock()
 	f, ok := codecFactoryMap[name]
+	codecFactoryLock.RUnlock()
 	if !ok {
 		return nil, perrors.Errorf("no actor codec implement named %s", name)
 	}
 	return f(), nil
 }
+
+// ListActorCodecs returns the names of all registered codecs in sorted order.
+func ListActorCodecs() []string {
+	codecFactoryLock.RLock()
+	defer codecFactoryLock.RUnlock()
+	names := make([]string, 0, len(codecFactoryMap))
+	for name := range codecFactoryMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,69 @@
+package codec
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubCodec struct{}
+
+func (s *stubCodec) Marshal(v interface{}) ([]byte, error) {
+	return []byte(fmt.Sprint(v)), nil
+}
+
+func (s *stubCodec) Unmarshal(data []byte, v interface{}) error {
+	return nil
+}
+
+func (s *stubCodec) ContentType() string {
+	return "text/plain"
+}
+
+func TestActorCodecRegistry(t *testing.T) {
+	_, err := GetActorCodec("stub0")
+	assert.Error(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			SetActorCodec(fmt.Sprintf("stub%d", i), func() Codec {
+				return &stubCodec{}
+			})
+			_, _ = GetActorCodec("stub0")
+			_ = ListActorCodecs()
+		}(i)
+	}
+	wg.Wait()
+
+	c, err := GetActorCodec("stub3")
+	assert.NoError(t, err)
+	assert.Equal(t, "text/plain", c.ContentType())
+	assert.Equal(t, []string{"stub0", "stub1", "stub2", "stub3", "stub4", "stub5", "stub6", "stub7", "stub8", "stub9"}, ListActorCodecs())
+}
+
+func TestMethodWithCodec(t *testing.T) {
+	tests := []struct {
+		codecName     string
+		expected      string
+		expectedCodec string
+	}{
+		{"", "Invoke", ""},
+		// the default codec is not declared
+		{"json", "Invoke", ""},
+		{"msgpack", "Invoke.msgpack", "msgpack"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			name := MethodWithCodec("Invoke", tt.codecName)
+			assert.Equal(t, tt.expected, name)
+			method, codecName := SplitMethodCodec(name)
+			assert.Equal(t, "Invoke", method)
+			assert.Equal(t, tt.expectedCodec, codecName)
+		})
+	}
+}
@@ -5,3 +5,21 @@ const DefaultSerializerType = "json"
 
 // YamlSerializerType is yaml actor invocation serialization type.
 const YamlSerializerType = "yaml"
+
+// ProtobufSerializerType is protobuf actor invocation serialization type, it only encodes proto.Message values.
+const ProtobufSerializerType = "protobuf"
+
+// MsgpackSerializerType is MessagePack actor invocation serialization type.
+const MsgpackSerializerType = "msgpack"
+
+// MethodCodecSeparator separates the actor method name from the name of the codec the actor client stubs declare, as
+// in Invoke.msgpack. Go method names can't hold it, so method names without it declare no codec.
+const MethodCodecSeparator = "."
+
+// content types reported by the built-in codecs.
+const (
+	JSONContentType     = "application/json"
+	YamlContentType     = "application/x-yaml"
+	ProtobufContentType = "application/x-protobuf"
+	MsgpackContentType  = "application/x-msgpack"
+)
@@ -0,0 +1,151 @@
+package impl
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/dapr/go-sdk/actor/codec"
+	"github.com/dapr/go-sdk/actor/codec/constant"
+)
+
+type user struct {
+	Name  string            `json:"name"`
+	Age   int64             `json:"age"`
+	Score float64           `json:"score"`
+	Tags  []string          `json:"tags"`
+	Attrs map[string]uint64 `json:"attrs"`
+	Raw   []byte            `json:"raw"`
+	Admin bool              `json:"admin"`
+	Boss  *user             `json:"boss"`
+}
+
+func TestBuiltinCodecs(t *testing.T) {
+	assert.Subset(t, codec.ListActorCodecs(), []string{
+		constant.DefaultSerializerType,
+		constant.YamlSerializerType,
+		constant.ProtobufSerializerType,
+		constant.MsgpackSerializerType,
+	})
+
+	tests := []struct {
+		name        string
+		contentType string
+	}{
+		{constant.DefaultSerializerType, "application/json"},
+		{constant.YamlSerializerType, "application/x-yaml"},
+		{constant.ProtobufSerializerType, "application/x-protobuf"},
+		{constant.MsgpackSerializerType, "application/x-msgpack"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := codec.GetActorCodec(tt.name)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.contentType, c.ContentType())
+		})
+	}
+}
+
+func TestMsgpackCodec(t *testing.T) {
+	c := &MsgpackCodec{}
+	in := &user{
+		Name:  "abc",
+		Age:   -1 << 40,
+		Score: 1.5,
+		Tags:  []string{"a", string(make([]byte, 40))},
+		Attrs: map[string]uint64{"max": 1<<64 - 1, "small": 7},
+		Raw:   []byte{0, 1, 2},
+		Admin: true,
+		Boss:  &user{Name: "boss", Age: 300},
+	}
+	data, err := c.Marshal(in)
+	assert.NoError(t, err)
+	out := &user{}
+	assert.NoError(t, c.Unmarshal(data, out))
+	assert.Equal(t, in, out)
+
+	// native MessagePack encodings
+	data, err = c.Marshal([]interface{}{nil, true, 1, -1, -200, "hi"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x96, 0xc0, 0xc3, 0x01, 0xff, 0xd1, 0xff, 0x38, 0xa2, 'h', 'i'}, data)
+	data, err = c.Marshal(map[string]interface{}{"b": 2, "a": 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x82, 0xa1, 'a', 0x01, 0xa1, 'b', 0x02}, data)
+
+	// binary and float32 values written by other MessagePack implementations
+	var raw []byte
+	assert.NoError(t, c.Unmarshal([]byte{0xc4, 0x02, 0xca, 0xfe}, &raw))
+	assert.Equal(t, []byte{0xca, 0xfe}, raw)
+	var f float64
+	assert.NoError(t, c.Unmarshal([]byte{0xca, 0x3f, 0xc0, 0x00, 0x00}, &f))
+	assert.Equal(t, 1.5, f)
+
+	// []byte values are binary values, and floats keep NaN and infinities
+	data, err = c.Marshal([]byte{0xca, 0xfe})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0xc4, 0x02, 0xca, 0xfe}, data)
+	data, err = c.Marshal([]float64{math.NaN(), math.Inf(1)})
+	assert.NoError(t, err)
+	var floats []float64
+	assert.NoError(t, c.Unmarshal(data, &floats))
+	assert.True(t, math.IsNaN(floats[0]))
+	assert.True(t, math.IsInf(floats[1], 1))
+
+	assert.Error(t, c.Unmarshal([]byte{0xa5, 'a'}, &raw))
+	assert.Error(t, c.Unmarshal([]byte{0x01, 0x02}, &f))
+	assert.Error(t, c.Unmarshal([]byte{0xc1}, &f))
+}
+
+func TestMsgpackCodecDepth(t *testing.T) {
+	c := &MsgpackCodec{}
+	nested := func(depth int) []byte {
+		return append(bytes.Repeat([]byte{0x91}, depth), 0x01)
+	}
+	var v interface{}
+	assert.NoError(t, c.Unmarshal(nested(maxMsgpackDepth), &v))
+	assert.Error(t, c.Unmarshal(nested(maxMsgpackDepth+1), &v))
+	// a payload of a few MB nesting arrays must be rejected rather than exhaust the stack
+	assert.Error(t, c.Unmarshal(nested(4<<20), &v))
+	assert.Error(t, codec.UnmarshalArgs(c, append([]byte{0x92, 0x01}, nested(4<<20)...), []interface{}{&v, &v}))
+}
+
+func TestMsgpackCodecArgs(t *testing.T) {
+	c := &MsgpackCodec{}
+	data, err := codec.MarshalArgs(c, []interface{}{"abc", 123, &user{Name: "abc"}})
+	assert.NoError(t, err)
+
+	var (
+		name string
+		age  int
+		usr  *user
+	)
+	assert.NoError(t, codec.UnmarshalArgs(c, data, []interface{}{&name, &age, &usr}))
+	assert.Equal(t, "abc", name)
+	assert.Equal(t, 123, age)
+	assert.Equal(t, "abc", usr.Name)
+	assert.Error(t, codec.UnmarshalArgs(c, data, []interface{}{&name, &age}))
+}
+
+func TestProtobufCodec(t *testing.T) {
+	c := &ProtobufCodec{}
+	data, err := c.Marshal(wrapperspb.String("abc"))
+	assert.NoError(t, err)
+
+	out := &wrapperspb.StringValue{}
+	assert.NoError(t, c.Unmarshal(data, out))
+	assert.Equal(t, "abc", out.GetValue())
+
+	// actor method arguments of type *StringValue are decoded into a **StringValue
+	var arg *wrapperspb.StringValue
+	assert.NoError(t, codec.UnmarshalArgs(c, data, []interface{}{&arg}))
+	assert.True(t, proto.Equal(wrapperspb.String("abc"), arg))
+
+	_, err = c.Marshal("abc")
+	assert.Error(t, err)
+	var s string
+	assert.Error(t, c.Unmarshal(data, &s))
+}
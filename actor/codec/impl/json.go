@@ -3,6 +3,8 @@ package impl
 import (
 	"encoding/json"
 
+	perrors "github.com/pkg/errors"
+
 	"github.com/dapr/go-sdk/actor/codec"
 	"github.com/dapr/go-sdk/actor/codec/constant"
 )
@@ -23,3 +25,24 @@ func (j *JSONCodec) Marshal(v interface{}) ([]byte, error) {
 func (j *JSONCodec) Unmarshal(data []byte, v interface{}) error {
 	return json.Unmarshal(data, v)
 }
+
+func (j *JSONCodec) ContentType() string {
+	return constant.JSONContentType
+}
+
+// UnmarshalArgs decodes the json array @data into @args element by element.
+func (j *JSONCodec) UnmarshalArgs(data []byte, args []interface{}) error {
+	elems := make([]json.RawMessage, 0, len(args))
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+	if len(elems) != len(args) {
+		return perrors.Errorf("expected %d arguments, got %d", len(args), len(elems))
+	}
+	for i, elem := range elems {
+		if err := json.Unmarshal(elem, args[i]); err != nil {
+			return perrors.Wrapf(err, "failed to decode argument %d", i)
+		}
+	}
+	return nil
+}
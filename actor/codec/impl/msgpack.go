@@ -0,0 +1,123 @@
+package impl
+
+import (
+	"bytes"
+
+	perrors "github.com/pkg/errors"
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+
+	"github.com/dapr/go-sdk/actor/codec"
+	"github.com/dapr/go-sdk/actor/codec/constant"
+)
+
+func init() {
+	codec.SetActorCodec(constant.MsgpackSerializerType, func() codec.Codec {
+		return &MsgpackCodec{}
+	})
+}
+
+// maxMsgpackDepth is the maximum nesting depth of the arrays and maps of decoded MessagePack data, it's the limit
+// of encoding/json, so that actors accept the same values whatever the codec. Invocation data comes from arbitrary
+// callers, and each nesting level costs one byte, so deeper data would exhaust the stack of the decoder.
+const maxMsgpackDepth = 10000
+
+// MsgpackCodec is MessagePack impl of codec.Codec.
+// Struct fields are named by their json tags like with JSONCodec, integers are encoded with the smallest MessagePack
+// type holding them, the keys of map[string]interface{} values are sorted, and []byte values are encoded as
+// MessagePack binary values.
+type MsgpackCodec struct{}
+
+func (m *MsgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	encoder := msgpack.NewEncoder(buf)
+	encoder.SetCustomStructTag("json")
+	encoder.SetSortMapKeys(true)
+	encoder.UseCompactInts(true)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (m *MsgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	if err := checkMsgpack(data); err != nil {
+		return err
+	}
+	return newMsgpackDecoder(data).Decode(v)
+}
+
+func (m *MsgpackCodec) ContentType() string {
+	return constant.MsgpackContentType
+}
+
+// UnmarshalArgs decodes the MessagePack array @data into @args element by element.
+func (m *MsgpackCodec) UnmarshalArgs(data []byte, args []interface{}) error {
+	if err := checkMsgpack(data); err != nil {
+		return err
+	}
+	decoder := newMsgpackDecoder(data)
+	n, err := decoder.DecodeArrayLen()
+	if err != nil {
+		return perrors.Wrapf(err, "expected an array of %d arguments", len(args))
+	}
+	if n != len(args) {
+		return perrors.Errorf("expected %d arguments, got %d", len(args), n)
+	}
+	for i, arg := range args {
+		if err := decoder.Decode(arg); err != nil {
+			return perrors.Wrapf(err, "failed to decode argument %d", i)
+		}
+	}
+	return nil
+}
+
+func newMsgpackDecoder(data []byte) *msgpack.Decoder {
+	decoder := msgpack.NewDecoder(bytes.NewReader(data))
+	decoder.SetCustomStructTag("json")
+	return decoder
+}
+
+// checkMsgpack checks that @data holds exactly one MessagePack value, whose arrays and maps are nested
+// maxMsgpackDepth levels at most. It walks the data without recursion, as the decoder recurses once per level.
+func checkMsgpack(data []byte) error {
+	reader := bytes.NewReader(data)
+	decoder := msgpack.NewDecoder(reader)
+	// remaining stores the count of values left in each enclosing array or map, the top level holds one value
+	remaining := []int{1}
+	for len(remaining) > 0 {
+		top := len(remaining) - 1
+		if remaining[top] == 0 {
+			remaining = remaining[:top]
+			continue
+		}
+		remaining[top]--
+		c, err := decoder.PeekCode()
+		if err != nil {
+			return perrors.Wrap(err, "invalid MessagePack data")
+		}
+		n := 0
+		switch {
+		case msgpcode.IsFixedArray(c), c == msgpcode.Array16, c == msgpcode.Array32:
+			n, err = decoder.DecodeArrayLen()
+		case msgpcode.IsFixedMap(c), c == msgpcode.Map16, c == msgpcode.Map32:
+			n, err = decoder.DecodeMapLen()
+			n *= 2
+		default:
+			err = decoder.Skip()
+		}
+		if err != nil {
+			return perrors.Wrap(err, "invalid MessagePack data")
+		}
+		if n > 0 {
+			if len(remaining) > maxMsgpackDepth {
+				return perrors.Errorf("MessagePack data is nested deeper than %d levels", maxMsgpackDepth)
+			}
+			remaining = append(remaining, n)
+		}
+	}
+	if reader.Len() > 0 {
+		return perrors.Errorf("invalid MessagePack data, %d bytes after the value", reader.Len())
+	}
+	return nil
+}
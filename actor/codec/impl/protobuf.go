@@ -0,0 +1,53 @@
+package impl
+
+import (
+	"reflect"
+
+	perrors "github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/dapr/go-sdk/actor/codec"
+	"github.com/dapr/go-sdk/actor/codec/constant"
+)
+
+func init() {
+	codec.SetActorCodec(constant.ProtobufSerializerType, func() codec.Codec {
+		return &ProtobufCodec{}
+	})
+}
+
+// ProtobufCodec is protobuf impl of codec.Codec, it only encodes proto.Message values, so actor methods using it
+// take at most one argument.
+type ProtobufCodec struct{}
+
+func (p *ProtobufCodec) Marshal(v interface{}) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, perrors.Errorf("protobuf codec can't marshal %T, which is not a proto.Message", v)
+	}
+	return proto.Marshal(msg)
+}
+
+// Unmarshal decodes @data into @v, which is either a proto.Message or a pointer to one, the latter is allocated
+// when nil, as actor method arguments of type *Message are decoded into a **Message.
+func (p *ProtobufCodec) Unmarshal(data []byte, v interface{}) error {
+	if msg, ok := v.(proto.Message); ok {
+		return proto.Unmarshal(data, msg)
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Ptr {
+		return perrors.Errorf("protobuf codec can't unmarshal into %T, which is not a proto.Message", v)
+	}
+	if rv.Elem().IsNil() {
+		rv.Elem().Set(reflect.New(rv.Elem().Type().Elem()))
+	}
+	msg, ok := rv.Elem().Interface().(proto.Message)
+	if !ok {
+		return perrors.Errorf("protobuf codec can't unmarshal into %T, which is not a proto.Message", v)
+	}
+	return proto.Unmarshal(data, msg)
+}
+
+func (p *ProtobufCodec) ContentType() string {
+	return constant.ProtobufContentType
+}
@@ -1,6 +1,8 @@
 package impl
 
 import (
+	perrors "github.com/pkg/errors"
+
 	"github.com/dapr/go-sdk/actor/codec"
 	"github.com/dapr/go-sdk/actor/codec/constant"
 
@@ -23,3 +25,24 @@ func (y *YamlCodec) Marshal(v interface{}) ([]byte, error) {
 func (y *YamlCodec) Unmarshal(data []byte, v interface{}) error {
 	return yaml.Unmarshal(data, v)
 }
+
+func (y *YamlCodec) ContentType() string {
+	return constant.YamlContentType
+}
+
+// UnmarshalArgs decodes the yaml sequence @data into @args element by element.
+func (y *YamlCodec) UnmarshalArgs(data []byte, args []interface{}) error {
+	elems := make([]yaml.Node, 0, len(args))
+	if err := yaml.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+	if len(elems) != len(args) {
+		return perrors.Errorf("expected %d arguments, got %d", len(args), len(elems))
+	}
+	for i := range elems {
+		if err := elems[i].Decode(args[i]); err != nil {
+			return perrors.Wrapf(err, "failed to decode argument %d", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package codec
+
+import (
+	"strings"
+
+	"github.com/dapr/go-sdk/actor/codec/constant"
+)
+
+// MethodWithCodec returns the name the actor method @method is invoked by, which declares the codec @codecName the
+// arguments and the reply are encoded with. The codec is carried by the method name, as Dapr forwards it to the
+// actor server as it is, unlike the invocation metadata. The default codec is not declared, so that the calls
+// encoded with it are understood by the actors of every Dapr SDK.
+func MethodWithCodec(method, codecName string) string {
+	if codecName == "" || codecName == constant.DefaultSerializerType {
+		return method
+	}
+	return method + constant.MethodCodecSeparator + codecName
+}
+
+// SplitMethodCodec splits the name @name an actor method is invoked by into the method and the codec it declares,
+// @codecName is empty if it declares none.
+func SplitMethodCodec(name string) (method, codecName string) {
+	if i := strings.Index(name, constant.MethodCodecSeparator); i >= 0 {
+		return name[:i], name[i+len(constant.MethodCodecSeparator):]
+	}
+	return name, ""
+}
@@ -1,10 +1,47 @@
 package config
 
-import "github.com/dapr/go-sdk/actor/codec/constant"
+import (
+	"time"
+
+	perrors "github.com/pkg/errors"
+
+	"github.com/dapr/go-sdk/actor"
+	"github.com/dapr/go-sdk/actor/codec/constant"
+	stateConstant "github.com/dapr/go-sdk/actor/state/constant"
+)
 
 // ActorConfig is Actor's configuration struct.
 type ActorConfig struct {
-	SerializerType string
+	SerializerType    string
+	StateProviderName string
+	StateCachePolicy  StateCachePolicy
+	// ClientStubs are validated against the actors of the actor type when it's registered.
+	ClientStubs []actor.Client
+
+	// The following fields are served to Dapr as actor runtime configuration, zero values are left to Dapr defaults.
+	ActorIdleTimeout           time.Duration
+	ActorScanInterval          time.Duration
+	DrainOngoingCallTimeout    time.Duration
+	DrainRebalancedActors      bool
+	Reentrancy                 *ReentrancyConfig
+	RemindersStoragePartitions int
+}
+
+// ReentrancyConfig is the reentrancy configuration of actors.
+type ReentrancyConfig struct {
+	Enabled bool
+	// MaxStackDepth is the max depth of reentrant calls, zero means Dapr default.
+	MaxStackDepth int
+}
+
+// StateCachePolicy is the policy of actor state cache, the states loaded or saved by an actor are kept in cache
+// between invocations by default.
+type StateCachePolicy struct {
+	// EvictAfterSave evicts all states from cache once they are saved, so that every invocation loads them again.
+	EvictAfterSave bool
+	// MaxEntries is the max number of states kept in cache of each actor after save, the least recently used ones
+	// are evicted first. Zero means no limit.
+	MaxEntries int
 }
 
 // Option is option function of ActorConfig.
@@ -17,10 +54,109 @@ func WithSerializerName(serializerType string) Option {
 	}
 }
 
+// WithStateProviderName set the state provider of the actor as the one registered as @stateProviderName.
+func WithStateProviderName(stateProviderName string) Option {
+	return func(config *ActorConfig) {
+		config.StateProviderName = stateProviderName
+	}
+}
+
+// WithStateCacheEvictAfterSave set the actor to evict its states from cache once they are saved.
+func WithStateCacheEvictAfterSave() Option {
+	return func(config *ActorConfig) {
+		config.StateCachePolicy.EvictAfterSave = true
+	}
+}
+
+// WithStateCacheMaxEntries set the actor to keep @maxEntries states in cache at most after save.
+func WithStateCacheMaxEntries(maxEntries int) Option {
+	return func(config *ActorConfig) {
+		config.StateCachePolicy.MaxEntries = maxEntries
+	}
+}
+
+// WithActorIdleTimeout sets the timeout after which an idle actor is deactivated.
+func WithActorIdleTimeout(timeout time.Duration) Option {
+	return func(config *ActorConfig) {
+		config.ActorIdleTimeout = timeout
+	}
+}
+
+// WithActorScanInterval sets the interval at which actors are scanned for idle timeout.
+func WithActorScanInterval(interval time.Duration) Option {
+	return func(config *ActorConfig) {
+		config.ActorScanInterval = interval
+	}
+}
+
+// WithDrainOngoingCallTimeout sets how long ongoing calls are drained when actors are rebalanced.
+func WithDrainOngoingCallTimeout(timeout time.Duration) Option {
+	return func(config *ActorConfig) {
+		config.DrainOngoingCallTimeout = timeout
+	}
+}
+
+// WithDrainRebalancedActors sets whether ongoing calls of rebalanced actors are drained before deactivation.
+func WithDrainRebalancedActors(drain bool) Option {
+	return func(config *ActorConfig) {
+		config.DrainRebalancedActors = drain
+	}
+}
+
+// WithReentrancy enables actor reentrancy, with @maxStackDepth reentrant calls at most, zero means Dapr default.
+func WithReentrancy(maxStackDepth int) Option {
+	return func(config *ActorConfig) {
+		config.Reentrancy = &ReentrancyConfig{
+			Enabled:       true,
+			MaxStackDepth: maxStackDepth,
+		}
+	}
+}
+
+// WithRemindersStoragePartitions sets the number of partitions the reminders of an actor type are stored in.
+func WithRemindersStoragePartitions(partitions int) Option {
+	return func(config *ActorConfig) {
+		config.RemindersStoragePartitions = partitions
+	}
+}
+
+// WithClientStubs sets the client stubs of the actor type to @stubs, the registration of the actor type fails if the
+// methods declared by one of them are not implemented by its actors with the same arguments and reply.
+func WithClientStubs(stubs ...actor.Client) Option {
+	return func(config *ActorConfig) {
+		config.ClientStubs = append(config.ClientStubs, stubs...)
+	}
+}
+
+// Validate checks the durations and numbers of the config are not negative.
+func (c *ActorConfig) Validate() error {
+	durations := map[string]time.Duration{
+		"actorIdleTimeout":        c.ActorIdleTimeout,
+		"actorScanInterval":       c.ActorScanInterval,
+		"drainOngoingCallTimeout": c.DrainOngoingCallTimeout,
+	}
+	for name, d := range durations {
+		if d < 0 {
+			return perrors.Errorf("invalid actor config %s: negative duration %s", name, d)
+		}
+	}
+	if c.RemindersStoragePartitions < 0 {
+		return perrors.Errorf("invalid actor config remindersStoragePartitions: negative number %d", c.RemindersStoragePartitions)
+	}
+	if c.StateCachePolicy.MaxEntries < 0 {
+		return perrors.Errorf("invalid actor config stateCacheMaxEntries: negative number %d", c.StateCachePolicy.MaxEntries)
+	}
+	if c.Reentrancy != nil && c.Reentrancy.MaxStackDepth < 0 {
+		return perrors.Errorf("invalid actor config reentrancy: negative max stack depth %d", c.Reentrancy.MaxStackDepth)
+	}
+	return nil
+}
+
 // GetConfigFromOptions get final ActorConfig set by @opts.
 func GetConfigFromOptions(opts ...Option) *ActorConfig {
 	conf := &ActorConfig{
-		SerializerType: constant.DefaultSerializerType,
+		SerializerType:    constant.DefaultSerializerType,
+		StateProviderName: stateConstant.DefaultStateProviderName,
 	}
 	for _, opt := range opts {
 		opt(conf)
@@ -2,8 +2,10 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/dapr/go-sdk/actor/codec/constant"
+	stateConstant "github.com/dapr/go-sdk/actor/state/constant"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -13,13 +15,48 @@ func TestRegisterActorTimer(t *testing.T) {
 		config := GetConfigFromOptions()
 		assert.NotNil(t, config)
 		assert.Equal(t, constant.DefaultSerializerType, config.SerializerType)
+		assert.Equal(t, stateConstant.DefaultStateProviderName, config.StateProviderName)
 	})
 
 	t.Run("get config with option", func(t *testing.T) {
 		config := GetConfigFromOptions(
 			WithSerializerName("mockSerializerType"),
+			WithStateProviderName(stateConstant.MemoryStateProviderName),
+			WithStateCacheEvictAfterSave(),
+			WithStateCacheMaxEntries(100),
 		)
 		assert.NotNil(t, config)
+		assert.Equal(t, StateCachePolicy{EvictAfterSave: true, MaxEntries: 100}, config.StateCachePolicy)
 		assert.Equal(t, "mockSerializerType", config.SerializerType)
+		assert.Equal(t, stateConstant.MemoryStateProviderName, config.StateProviderName)
+	})
+}
+
+func TestRuntimeOptions(t *testing.T) {
+	t.Run("get config with runtime options", func(t *testing.T) {
+		config := GetConfigFromOptions(
+			WithActorIdleTimeout(time.Hour),
+			WithActorScanInterval(30*time.Second),
+			WithDrainOngoingCallTimeout(time.Minute),
+			WithDrainRebalancedActors(true),
+			WithReentrancy(16),
+			WithRemindersStoragePartitions(7),
+		)
+		assert.Equal(t, time.Hour, config.ActorIdleTimeout)
+		assert.Equal(t, 30*time.Second, config.ActorScanInterval)
+		assert.Equal(t, time.Minute, config.DrainOngoingCallTimeout)
+		assert.True(t, config.DrainRebalancedActors)
+		assert.Equal(t, &ReentrancyConfig{Enabled: true, MaxStackDepth: 16}, config.Reentrancy)
+		assert.Equal(t, 7, config.RemindersStoragePartitions)
+		assert.NoError(t, config.Validate())
+	})
+
+	t.Run("validate invalid config", func(t *testing.T) {
+		assert.Error(t, GetConfigFromOptions(WithActorIdleTimeout(-time.Second)).Validate())
+		assert.Error(t, GetConfigFromOptions(WithActorScanInterval(-time.Second)).Validate())
+		assert.Error(t, GetConfigFromOptions(WithDrainOngoingCallTimeout(-time.Second)).Validate())
+		assert.Error(t, GetConfigFromOptions(WithReentrancy(-1)).Validate())
+		assert.Error(t, GetConfigFromOptions(WithRemindersStoragePartitions(-1)).Validate())
+		assert.Error(t, GetConfigFromOptions(WithStateCacheMaxEntries(-1)).Validate())
 	})
 }
@@ -0,0 +1,96 @@
+package actor
+
+import (
+	"context"
+
+	perrors "github.com/pkg/errors"
+
+	"github.com/dapr/go-sdk/actor/codec"
+)
+
+// ArgsDecoder decodes the arguments of an actor invocation into the given pointers, in the order of the method
+// signature.
+type ArgsDecoder func(args ...interface{}) error
+
+// Method is one entry of a DispatchTable.
+type Method struct {
+	// HasReply reports whether the method returns a reply value besides error.
+	HasReply bool
+	// Invoke decodes the arguments with decode, calls the method and returns its reply.
+	Invoke func(ctx context.Context, decode ArgsDecoder) (interface{}, error)
+}
+
+// DispatchTable maps actor method names to reflection free invokers, it is generated by cmd/actorgen.
+type DispatchTable map[string]Method
+
+// Dispatcher is implemented by actor servers with a generated DispatchTable. The actor container invokes methods
+// found in the table directly, and falls back to reflection for other methods.
+type Dispatcher interface {
+	DispatchTable() DispatchTable
+}
+
+// Invoker sends the encoded actor invocation @data to the target actor, and returns the encoded reply.
+type Invoker func(ctx context.Context, actorType, actorID, method string, data []byte) ([]byte, error)
+
+// BindableClient is implemented by client stubs generated by cmd/actorgen. They are bound to a Dapr client and
+// codec when passed to ImplActorClientStub, instead of having their methods built by reflection.
+type BindableClient interface {
+	Client
+	BindInvoker(invoker Invoker, c codec.Codec)
+	SetID(actorID string)
+}
+
+// ClientStubBase is embedded by client stubs generated by cmd/actorgen, it impls BindableClient.
+type ClientStubBase struct {
+	actorType string
+	actorID   string
+	invoker   Invoker
+	codec     codec.Codec
+}
+
+// NewClientStubBase creates a ClientStubBase targeting actor @actorID of type @actorType.
+func NewClientStubBase(actorType, actorID string) ClientStubBase {
+	return ClientStubBase{
+		actorType: actorType,
+		actorID:   actorID,
+	}
+}
+
+func (b *ClientStubBase) Type() string {
+	return b.actorType
+}
+
+func (b *ClientStubBase) ID() string {
+	return b.actorID
+}
+
+// SetID retargets the stub to actor @actorID, it's called by ImplActorClientStubWithID.
+func (b *ClientStubBase) SetID(actorID string) {
+	b.actorID = actorID
+}
+
+// BindInvoker is called by ImplActorClientStub to bind the stub to a Dapr client and codec.
+func (b *ClientStubBase) BindInvoker(invoker Invoker, c codec.Codec) {
+	b.invoker = invoker
+	b.codec = c
+}
+
+// Invoke encodes @args positionally, invokes @method on the target actor and decodes the reply into @reply,
+// which is nil for methods only returning error.
+func (b *ClientStubBase) Invoke(ctx context.Context, method string, reply interface{}, args ...interface{}) error {
+	if b.invoker == nil || b.codec == nil {
+		return perrors.Errorf("actor client stub of %s is not bound to a dapr client", b.actorType)
+	}
+	data, err := codec.MarshalArgs(b.codec, args)
+	if err != nil {
+		return perrors.Wrapf(err, "error marshaling arguments of actor method %s", method)
+	}
+	rsp, err := b.invoker(ctx, b.actorType, b.actorID, method, data)
+	if err != nil || reply == nil {
+		return err
+	}
+	if err := b.codec.Unmarshal(rsp, reply); err != nil {
+		return perrors.Wrapf(err, "error unmarshaling response of actor method %s", method)
+	}
+	return nil
+}
@@ -1,8 +1,15 @@
 package error
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ActorErr is the code classifying actor failures. It implements error, so that the codes are the sentinels actor
+// failures are matched against with errors.Is.
 type ActorErr uint8
 
-// TODO(@laurence) the classification, handle and print log of error should be optimized.
 const (
 	Success                       = ActorErr(0)
 	ErrActorTypeNotFound          = ActorErr(1)
@@ -17,4 +24,120 @@ const (
 	ErrTimerParamsInvalid         = ActorErr(10)
 	ErrSaveStateFailed            = ActorErr(11)
 	ErrActorServerInvalid         = ActorErr(12)
+	ErrDaprClientNotAvailable     = ActorErr(13)
+	ErrStateProviderNotFound      = ActorErr(14)
+	ErrActorScheduleDone          = ActorErr(15)
 )
+
+var messages = map[ActorErr]string{
+	Success:                       "success",
+	ErrActorTypeNotFound:          "actor type not found",
+	ErrRemindersParamsInvalid:     "invalid reminder params",
+	ErrActorMethodNoFound:         "actor method not found",
+	ErrActorInvokeFailed:          "actor invocation failed",
+	ErrReminderFuncUndefined:      "actor can't handle reminders",
+	ErrActorMethodSerializeFailed: "failed to serialize actor method params or reply",
+	ErrActorSerializeNoFound:      "actor serializer not found",
+	ErrActorIDNotFound:            "actor id not found",
+	ErrActorFactoryNotSet:         "actor factory not set",
+	ErrTimerParamsInvalid:         "invalid timer params",
+	ErrSaveStateFailed:            "failed to save actor state",
+	ErrActorServerInvalid:         "invalid actor server",
+	ErrDaprClientNotAvailable:     "dapr client not available",
+	ErrStateProviderNotFound:      "actor state provider not found",
+	ErrActorScheduleDone:          "actor reminder or timer done",
+}
+
+func (e ActorErr) Error() string {
+	if msg, ok := messages[e]; ok {
+		return msg
+	}
+	return fmt.Sprintf("unknown actor error %d", uint8(e))
+}
+
+// Error is an actor failure classified by Code, with the actor and method it happens to, and its cause.
+type Error struct {
+	Code      ActorErr
+	ActorType string
+	ActorID   string
+	Method    string
+	Err       error
+}
+
+// New creates the actor failure of @code caused by @cause, which may be nil.
+func New(code ActorErr, cause error) *Error {
+	return &Error{
+		Code: code,
+		Err:  cause,
+	}
+}
+
+// WithActor sets the actor the failure happens to.
+func (e *Error) WithActor(actorType, actorID string) *Error {
+	e.ActorType = actorType
+	e.ActorID = actorID
+	return e
+}
+
+// WithMethod sets the method, reminder or timer the failure happens to.
+func (e *Error) WithMethod(method string) *Error {
+	e.Method = method
+	return e
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Code.Error())
+	context := make([]string, 0, 3)
+	if e.ActorType != "" {
+		context = append(context, "type="+e.ActorType)
+	}
+	if e.ActorID != "" {
+		context = append(context, "id="+e.ActorID)
+	}
+	if e.Method != "" {
+		context = append(context, "method="+e.Method)
+	}
+	if len(context) > 0 {
+		b.WriteString(" (" + strings.Join(context, ", ") + ")")
+	}
+	if e.Err != nil {
+		b.WriteString(": " + e.Err.Error())
+	}
+	return b.String()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether @target is the code of the failure.
+func (e *Error) Is(target error) bool {
+	code, ok := target.(ActorErr)
+	return ok && code == e.Code
+}
+
+// WithActorType sets the actor type of the actor failure @err if it's not set yet, and returns @err.
+func WithActorType(err error, actorType string) error {
+	var actorError *Error
+	if errors.As(err, &actorError) && actorError.ActorType == "" {
+		actorError.ActorType = actorType
+	}
+	return err
+}
+
+// CodeOf returns the code of @err, Success if @err is nil and ErrActorInvokeFailed if @err is not an actor failure.
+func CodeOf(err error) ActorErr {
+	if err == nil {
+		return Success
+	}
+	var actorError *Error
+	if errors.As(err, &actorError) {
+		return actorError.Code
+	}
+	var code ActorErr
+	if errors.As(err, &code) {
+		return code
+	}
+	return ErrActorInvokeFailed
+}
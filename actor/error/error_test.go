@@ -0,0 +1,58 @@
+package error
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActorErr(t *testing.T) {
+	assert.Equal(t, "actor type not found", ErrActorTypeNotFound.Error())
+	assert.Equal(t, "unknown actor error 255", ActorErr(255).Error())
+}
+
+func TestError(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := fmt.Errorf("turn failed: %w", New(ErrSaveStateFailed, cause).WithActor("testActorType", "testActorID").WithMethod("Invoke"))
+
+	assert.Equal(t, "turn failed: failed to save actor state (type=testActorType, id=testActorID, method=Invoke): connection refused", err.Error())
+	assert.ErrorIs(t, err, ErrSaveStateFailed)
+	assert.ErrorIs(t, err, cause)
+	assert.False(t, errors.Is(err, ErrActorInvokeFailed))
+	var actorError *Error
+	assert.True(t, errors.As(err, &actorError))
+	assert.Equal(t, "testActorID", actorError.ActorID)
+
+	assert.Equal(t, "actor id not found", New(ErrActorIDNotFound, nil).Error())
+}
+
+func TestWithActorType(t *testing.T) {
+	err := WithActorType(New(ErrActorMethodNoFound, nil).WithActor("", "testActorID"), "testActorType")
+	assert.Equal(t, "actor method not found (type=testActorType, id=testActorID)", err.Error())
+	// the type already set is kept
+	err = WithActorType(err, "otherActorType")
+	assert.Equal(t, "actor method not found (type=testActorType, id=testActorID)", err.Error())
+
+	assert.Nil(t, WithActorType(nil, "testActorType"))
+}
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code ActorErr
+	}{
+		{"nil", nil, Success},
+		{"code", ErrActorIDNotFound, ErrActorIDNotFound},
+		{"error", New(ErrTimerParamsInvalid, errors.New("bad json")), ErrTimerParamsInvalid},
+		{"wrapped error", fmt.Errorf("wrapped: %w", New(ErrSaveStateFailed, nil)), ErrSaveStateFailed},
+		{"not actor error", errors.New("failed"), ErrActorInvokeFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.code, CodeOf(tt.err))
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package actor
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	perrors "github.com/pkg/errors"
+)
+
+const (
+	day = 24 * time.Hour
+	// year and month are approximated, as ISO 8601 durations are parsed without a reference time.
+	year  = 365 * day
+	month = 30 * day
+)
+
+var (
+	iso8601Duration   = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+	iso8601Repetition = regexp.MustCompile(`^R(\d+)/(.+)$`)
+)
+
+// FormatISO8601Duration formats @d as ISO 8601 duration of hours, minutes and seconds, e.g. PT1H30M.
+func FormatISO8601Duration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	var b strings.Builder
+	b.WriteString("PT")
+	if h := d / time.Hour; h > 0 {
+		b.WriteString(strconv.FormatInt(int64(h), 10) + "H")
+		d -= h * time.Hour
+	}
+	if m := d / time.Minute; m > 0 {
+		b.WriteString(strconv.FormatInt(int64(m), 10) + "M")
+		d -= m * time.Minute
+	}
+	if d > 0 {
+		b.WriteString(strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "S")
+	}
+	return b.String()
+}
+
+// ParseISO8601Duration parses ISO 8601 duration @s, e.g. P1DT2H or PT10S. Years and months are approximated as 365
+// and 30 days.
+func ParseISO8601Duration(s string) (time.Duration, error) {
+	match := iso8601Duration.FindStringSubmatch(s)
+	if match == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, perrors.Errorf("invalid ISO 8601 duration %s", s)
+	}
+	var d time.Duration
+	for i, unit := range []time.Duration{year, month, 7 * day, day, time.Hour, time.Minute} {
+		if match[i+1] == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(match[i+1], 10, 64)
+		if err != nil {
+			return 0, perrors.Wrapf(err, "invalid ISO 8601 duration %s", s)
+		}
+		d += time.Duration(n) * unit
+	}
+	if match[7] != "" {
+		seconds, err := strconv.ParseFloat(match[7], 64)
+		if err != nil {
+			return 0, perrors.Wrapf(err, "invalid ISO 8601 duration %s", s)
+		}
+		d += time.Duration(seconds * float64(time.Second))
+	}
+	return d, nil
+}
+
+// parseDuration parses @s as Go duration or ISO 8601 duration.
+func parseDuration(s string) (time.Duration, error) {
+	if strings.HasPrefix(s, "P") {
+		return ParseISO8601Duration(s)
+	}
+	return time.ParseDuration(s)
+}
+
+// parseScheduleTime parses due time or ttl @s as duration or RFC 3339 time, which is converted to the duration
+// from now.
+func parseScheduleTime(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, nil
+		}
+		return 0, nil
+	}
+	return parseDuration(s)
+}
+
+// parsePeriod parses period @s as duration, or ISO 8601 repetition of a duration.
+func parsePeriod(s string) (time.Duration, int, error) {
+	if s == "" {
+		return 0, 0, nil
+	}
+	if match := iso8601Repetition.FindStringSubmatch(s); match != nil {
+		repetitions, err := strconv.Atoi(match[1])
+		if err != nil {
+			return 0, 0, perrors.Wrapf(err, "invalid repetitions %s", match[1])
+		}
+		period, err := parseDuration(match[2])
+		if err != nil {
+			return 0, 0, err
+		}
+		return period, repetitions, nil
+	}
+	period, err := parseDuration(s)
+	return period, 0, err
+}
@@ -2,72 +2,147 @@ package manager
 
 import (
 	"context"
-	"log"
 	"reflect"
 
+	perrors "github.com/pkg/errors"
+
 	"github.com/dapr/go-sdk/actor"
 	"github.com/dapr/go-sdk/actor/codec"
 	actorErr "github.com/dapr/go-sdk/actor/error"
-	"github.com/dapr/go-sdk/actor/state"
-	dapr "github.com/dapr/go-sdk/client"
 )
 
 type ActorContainer interface {
-	Invoke(methodName string, param []byte) ([]reflect.Value, actorErr.ActorErr)
+	// Invoke calls actor method @methodName with encoded @param, and returns the encoded reply, which is nil if the
+	// method only returns error.
+	Invoke(methodName string, param []byte) ([]byte, error)
+	// InvokeWithCodec calls actor method @methodName as Invoke does, with @param and the reply encoded by @c rather
+	// than the serializer of the container.
+	InvokeWithCodec(methodName string, c codec.Codec, param []byte) ([]byte, error)
+	// HasMethod returns true if the actor has method @methodName to be invoked.
+	HasMethod(methodName string) bool
 	GetActor() actor.Server
 }
 
 // DefaultActorContainer contains actor instance and methods type info generated from actor.
 type DefaultActorContainer struct {
-	methodType map[string]*MethodType
-	actor      actor.Server
-	serializer codec.Codec
+	methodType    map[string]*MethodType
+	dispatchTable actor.DispatchTable
+	actor         actor.Server
+	serializer    codec.Codec
+	// turns runs the turns of the actor one at a time
+	turns *turnGate
 }
 
-// NewDefaultActorContainer creates a new ActorContainer with provider impl actor and serializer.
-func NewDefaultActorContainer(actorID string, impl actor.Server, serializer codec.Codec) (ActorContainer, actorErr.ActorErr) {
+// NewDefaultActorContainer creates a new ActorContainer with provider impl actor and serializer, the state of the actor
+// is accessed through @stateManager.
+func NewDefaultActorContainer(actorID string, impl actor.Server, serializer codec.Codec, stateManager actor.StateManager) (ActorContainer, error) {
 	impl.SetID(actorID)
-	daprClient, _ := dapr.NewClient()
-	// create state manager for this new actor
-	impl.SetStateManager(state.NewActorStateManager(impl.Type(), actorID, state.NewDaprStateAsyncProvider(daprClient)))
+	// inject state manager for this new actor
+	impl.SetStateManager(stateManager)
 	// save state of this actor
 	err := impl.SaveState()
 	if err != nil {
-		return nil, actorErr.ErrSaveStateFailed
+		return nil, actorErr.New(actorErr.ErrSaveStateFailed, err)
 	}
 	methodType, err := getAbsctractMethodMap(impl)
 	if err != nil {
-		log.Printf("failed to get absctract method map from registered provider, err = %s", err)
-		return nil, actorErr.ErrActorServerInvalid
+		return nil, actorErr.New(actorErr.ErrActorServerInvalid, err)
+	}
+	var dispatchTable actor.DispatchTable
+	if dispatcher, ok := impl.(actor.Dispatcher); ok {
+		dispatchTable = dispatcher.DispatchTable()
 	}
 	return &DefaultActorContainer{
-		methodType: methodType,
-		actor:      impl,
-		serializer: serializer,
-	}, actorErr.Success
+		methodType:    methodType,
+		dispatchTable: dispatchTable,
+		actor:         impl,
+		serializer:    serializer,
+		turns:         newTurnGate(),
+	}, nil
 }
 
 func (d *DefaultActorContainer) GetActor() actor.Server {
 	return d.actor
 }
 
-// Invoke call actor method with given methodName and param.
-func (d *DefaultActorContainer) Invoke(methodName string, param []byte) ([]reflect.Value, actorErr.ActorErr) {
+func (d *DefaultActorContainer) HasMethod(methodName string) bool {
+	if _, ok := d.dispatchTable[methodName]; ok {
+		return true
+	}
+	_, ok := d.methodType[methodName]
+	return ok
+}
+
+// Invoke call actor method with given methodName and param. Methods of the generated dispatch table of the actor are
+// called directly, others are called by reflection.
+func (d *DefaultActorContainer) Invoke(methodName string, param []byte) ([]byte, error) {
+	return d.InvokeWithCodec(methodName, d.serializer, param)
+}
+
+func (d *DefaultActorContainer) InvokeWithCodec(methodName string, c codec.Codec, param []byte) ([]byte, error) {
+	if method, ok := d.dispatchTable[methodName]; ok {
+		return d.dispatch(methodName, method, c, param)
+	}
 	methodType, ok := d.methodType[methodName]
 	if !ok {
-		return nil, actorErr.ErrActorMethodNoFound
-	}
-	argsValues := make([]reflect.Value, 0)
-	argsValues = append(argsValues, reflect.ValueOf(d.actor), reflect.ValueOf(context.Background()))
-	if len(methodType.argsType) > 0 {
-		typ := methodType.argsType[0]
-		paramValue := reflect.New(typ)
-		paramInterface := paramValue.Interface()
-		if err := d.serializer.Unmarshal(param, paramInterface); err != nil {
-			return nil, actorErr.ErrActorMethodSerializeFailed
-		}
-		argsValues = append(argsValues, reflect.ValueOf(paramInterface).Elem())
+		return nil, actorErr.New(actorErr.ErrActorMethodNoFound, nil).WithMethod(methodName)
+	}
+	argsValues := make([]reflect.Value, 0, len(methodType.argsType)+2)
+	argsValues = append(argsValues, reflect.ValueOf(d.actor))
+	if methodType.ctxType != nil {
+		argsValues = append(argsValues, reflect.ValueOf(context.Background()))
+	}
+	args := make([]interface{}, len(methodType.argsType))
+	for i, typ := range methodType.argsType {
+		args[i] = reflect.New(typ).Interface()
+	}
+	if err := codec.UnmarshalArgs(c, param, args); err != nil {
+		return nil, actorErr.New(actorErr.ErrActorMethodSerializeFailed, perrors.Wrap(err, "failed to decode arguments")).WithMethod(methodName)
+	}
+	for _, arg := range args {
+		argsValues = append(argsValues, reflect.ValueOf(arg).Elem())
 	}
 	returnValue := methodType.method.Func.Call(argsValues)
-	return returnValue, actorErr.Success
+	if retErr := returnValue[len(returnValue)-1].Interface(); retErr != nil {
+		return nil, invokeFailed(methodName, retErr.(error))
+	}
+	if methodType.replyType == nil {
+		return nil, nil
+	}
+	return marshalReply(methodName, c, returnValue[0].Interface())
+}
+
+func (d *DefaultActorContainer) dispatch(methodName string, method actor.Method, c codec.Codec, param []byte) ([]byte, error) {
+	var decodeErr error
+	decode := func(args ...interface{}) error {
+		decodeErr = codec.UnmarshalArgs(c, param, args)
+		return decodeErr
+	}
+	reply, err := method.Invoke(context.Background(), decode)
+	if decodeErr != nil {
+		return nil, actorErr.New(actorErr.ErrActorMethodSerializeFailed, perrors.Wrap(decodeErr, "failed to decode arguments")).WithMethod(methodName)
+	}
+	if err != nil {
+		return nil, invokeFailed(methodName, err)
+	}
+	if !method.HasReply {
+		return nil, nil
+	}
+	return marshalReply(methodName, c, reply)
+}
+
+func marshalReply(methodName string, c codec.Codec, reply interface{}) ([]byte, error) {
+	rspData, err := c.Marshal(reply)
+	if err != nil {
+		return nil, actorErr.New(actorErr.ErrActorMethodSerializeFailed, perrors.Wrap(err, "failed to encode reply")).WithMethod(methodName)
+	}
+	return rspData, nil
+}
+
+// invokeFailed classifies the error @err returned by actor method @methodName.
+func invokeFailed(methodName string, err error) error {
+	if perrors.Is(err, actor.ErrScheduleDone) {
+		return actorErr.New(actorErr.ErrActorScheduleDone, err).WithMethod(methodName)
+	}
+	return actorErr.New(actorErr.ErrActorInvokeFailed, err).WithMethod(methodName)
 }
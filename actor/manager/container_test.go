@@ -1,16 +1,24 @@
 package manager
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/dapr/go-sdk/actor"
+	"github.com/dapr/go-sdk/actor/codec"
 	actorErr "github.com/dapr/go-sdk/actor/error"
 	actorMock "github.com/dapr/go-sdk/actor/mock"
+	"github.com/dapr/go-sdk/actor/state"
 )
 
-const mockActorID = "mockActorID"
+const (
+	mockActorID   = "mockActorID"
+	mockActorType = "mockActorType"
+)
 
 func TestNewDefaultContainer(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -21,10 +29,9 @@ func TestNewDefaultContainer(t *testing.T) {
 	mockServer.EXPECT().SetID(mockActorID)
 	mockServer.EXPECT().SetStateManager(gomock.Any())
 	mockServer.EXPECT().SaveState()
-	mockServer.EXPECT().Type()
 
-	newContainer, aerr := NewDefaultActorContainer(mockActorID, mockServer, mockCodec)
-	assert.Equal(t, actorErr.Success, aerr)
+	newContainer, aerr := NewDefaultActorContainer(mockActorID, mockServer, mockCodec, state.NewActorStateManager(mockActorType, mockActorID, state.NewDaprStateAsyncProvider(actorMock.NewDaprClient())))
+	assert.NoError(t, aerr)
 	container, ok := newContainer.(*DefaultActorContainer)
 
 	assert.True(t, ok)
@@ -44,18 +51,81 @@ func TestContainerInvoke(t *testing.T) {
 	mockServer.EXPECT().SetID(mockActorID)
 	mockServer.EXPECT().SetStateManager(gomock.Any())
 	mockServer.EXPECT().SaveState()
-	mockServer.EXPECT().Type()
 
-	newContainer, aerr := NewDefaultActorContainer("mockActorID", mockServer, mockCodec)
-	assert.Equal(t, actorErr.Success, aerr)
+	newContainer, aerr := NewDefaultActorContainer("mockActorID", mockServer, mockCodec, state.NewActorStateManager(mockActorType, mockActorID, state.NewDaprStateAsyncProvider(actorMock.NewDaprClient())))
+	assert.NoError(t, aerr)
 	container := newContainer.(*DefaultActorContainer)
 
 	mockServer.EXPECT().Invoke(gomock.Any(), "param").Return(param, nil)
 	mockCodec.EXPECT().Unmarshal([]byte(param), gomock.Any()).SetArg(1, "param").Return(nil)
+	mockCodec.EXPECT().Marshal(param).Return([]byte(param), nil)
 
 	rsp, err := container.Invoke("Invoke", []byte(param))
 
-	assert.Equal(t, 2, len(rsp))
-	assert.Equal(t, actorErr.Success, err)
-	assert.Equal(t, param, rsp[0].Interface().(string))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(param), rsp)
+}
+
+type DispatchActor struct {
+	actor.ServerImplBase
+}
+
+func (a *DispatchActor) Type() string {
+	return "dispatchActorType"
+}
+
+func (a *DispatchActor) Echo(ctx context.Context, req string) (string, error) {
+	return "reflect:" + req, nil
+}
+
+func (a *DispatchActor) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (a *DispatchActor) DispatchTable() actor.DispatchTable {
+	return actor.DispatchTable{
+		"Echo": {
+			HasReply: true,
+			Invoke: func(ctx context.Context, decode actor.ArgsDecoder) (interface{}, error) {
+				var arg0 string
+				if err := decode(&arg0); err != nil {
+					return nil, err
+				}
+				return "dispatch:" + arg0, nil
+			},
+		},
+		"Fail": {
+			Invoke: func(ctx context.Context, decode actor.ArgsDecoder) (interface{}, error) {
+				return nil, errors.New("fail")
+			},
+		},
+	}
+}
+
+func TestContainerInvokeDispatchTable(t *testing.T) {
+	serializer, err := codec.GetActorCodec("json")
+	assert.NoError(t, err)
+	container, aerr := NewDefaultActorContainer(mockActorID, &DispatchActor{}, serializer, state.NewActorStateManager(mockActorType, mockActorID, state.NewDaprStateAsyncProvider(actorMock.NewDaprClient())))
+	assert.NoError(t, aerr)
+
+	rsp, aerr := container.Invoke("Echo", []byte(`"hello"`))
+	assert.NoError(t, aerr)
+	assert.Equal(t, []byte(`"dispatch:hello"`), rsp)
+
+	rsp, aerr = container.Invoke("Echo", []byte(`1`))
+	assert.Nil(t, rsp)
+	assert.ErrorIs(t, aerr, actorErr.ErrActorMethodSerializeFailed)
+
+	rsp, aerr = container.Invoke("Fail", nil)
+	assert.Nil(t, rsp)
+	assert.ErrorIs(t, aerr, actorErr.ErrActorInvokeFailed)
+
+	// methods missing from the dispatch table fall back to reflection
+	rsp, aerr = container.Invoke("Ping", nil)
+	assert.Nil(t, rsp)
+	assert.NoError(t, aerr)
+
+	rsp, aerr = container.Invoke("DispatchTable", nil)
+	assert.Nil(t, rsp)
+	assert.ErrorIs(t, aerr, actorErr.ErrActorMethodNoFound)
 }
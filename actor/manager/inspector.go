@@ -0,0 +1,94 @@
+package manager
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+
+	perrors "github.com/pkg/errors"
+
+	"github.com/dapr/go-sdk/actor"
+	actorErr "github.com/dapr/go-sdk/actor/error"
+	"github.com/dapr/go-sdk/actor/state"
+	dapr "github.com/dapr/go-sdk/client"
+)
+
+// ActorInspector is implemented by actor managers exposing their actors to admin tooling, so that actors can be
+// debugged in production.
+type ActorInspector interface {
+	// ActiveActorIDs returns the sorted IDs of the active actors.
+	ActiveActorIDs() []string
+	// EnableStateDump makes the actors keep the snapshot of their states dumped by DumpState, from their next turn.
+	EnableStateDump()
+	// DumpState returns the states in the cache of active actor @actorID, as they were at the end of its last turn.
+	DumpState(actorID string) ([]state.CachedState, error)
+	// GetPersistedState reads the state @stateName of actor @actorID as persisted by Dapr, bypassing the cache.
+	GetPersistedState(actorID, stateName string) ([]byte, error)
+}
+
+func (m *DefaultActorManager) ActiveActorIDs() []string {
+	actorIDs := make([]string, 0)
+	m.activeActors.Range(func(key, value interface{}) bool {
+		actorIDs = append(actorIDs, key.(string))
+		return true
+	})
+	sort.Strings(actorIDs)
+	return actorIDs
+}
+
+func (m *DefaultActorManager) EnableStateDump() {
+	atomic.StoreInt32(&m.stateDump, 1)
+	m.activeActors.Range(func(key, value interface{}) bool {
+		if stateManager, err := stateManagerOf(value.(ActorContainer)); err == nil {
+			stateManager.EnableSnapshots()
+		}
+		return true
+	})
+}
+
+func (m *DefaultActorManager) DumpState(actorID string) ([]state.CachedState, error) {
+	val, ok := m.activeActors.Load(actorID)
+	if !ok {
+		return nil, actorErr.New(actorErr.ErrActorIDNotFound, nil).WithActor("", actorID)
+	}
+	stateManager, err := stateManagerOf(val.(ActorContainer))
+	if err != nil {
+		return nil, err
+	}
+	return stateManager.Dump(), nil
+}
+
+// stateManagerOf returns the state manager of the actor of @actorContainer.
+func stateManagerOf(actorContainer ActorContainer) (*state.ActorStateManager, error) {
+	impl := actorContainer.GetActor()
+	holder, ok := impl.(interface {
+		GetStateManager() actor.StateManager
+	})
+	if !ok {
+		return nil, perrors.Errorf("actor %T has no state manager", impl)
+	}
+	stateManager, ok := holder.GetStateManager().(*state.ActorStateManager)
+	if !ok {
+		return nil, perrors.Errorf("state manager %T of actor %s can't be dumped", holder.GetStateManager(), impl.ID())
+	}
+	return stateManager, nil
+}
+
+func (m *DefaultActorManager) GetPersistedState(actorID, stateName string) ([]byte, error) {
+	if m.getFactory() == nil {
+		return nil, actorErr.New(actorErr.ErrActorFactoryNotSet, nil).WithActor("", actorID)
+	}
+	daprClient, err := m.getDaprClient()
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := daprClient.GetActorState(context.Background(), &dapr.GetActorStateRequest{
+		ActorType: m.registeredType(),
+		ActorID:   actorID,
+		KeyName:   stateName,
+	})
+	if err != nil {
+		return nil, perrors.Wrapf(err, "failed to get state %s of actor %s", stateName, actorID)
+	}
+	return rsp.Data, nil
+}
@@ -1,11 +1,14 @@
 package manager
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"reflect"
 	"sync"
+	"sync/atomic"
+	"time"
 	"unicode"
 	"unicode/utf8"
 
@@ -14,148 +17,464 @@ import (
 	"github.com/dapr/go-sdk/actor"
 	"github.com/dapr/go-sdk/actor/api"
 	"github.com/dapr/go-sdk/actor/codec"
+	"github.com/dapr/go-sdk/actor/config"
 	actorErr "github.com/dapr/go-sdk/actor/error"
+	"github.com/dapr/go-sdk/actor/metrics"
+	"github.com/dapr/go-sdk/actor/state"
+	stateConstant "github.com/dapr/go-sdk/actor/state/constant"
+	dapr "github.com/dapr/go-sdk/client"
 )
 
 type ActorManager interface {
 	RegisterActorImplFactory(f actor.Factory)
-	InvokeMethod(actorID, methodName string, request []byte) ([]byte, actorErr.ActorErr)
-	DetectiveActor(actorID string) actorErr.ActorErr
-	InvokeReminder(actorID, reminderName string, params []byte) actorErr.ActorErr
-	InvokeTimer(actorID, timerName string, params []byte) actorErr.ActorErr
+	InvokeMethod(actorID, methodName string, request []byte) ([]byte, error)
+	// InvokeMethodWithCodec invokes the method as InvokeMethod does, with @request and the response encoded by the
+	// codec named @codecName, or by the serializer of the manager if it's empty.
+	InvokeMethodWithCodec(actorID, methodName, codecName string, request []byte) ([]byte, error)
+	DetectiveActor(actorID string) error
+	InvokeReminder(actorID, reminderName string, params []byte) error
+	InvokeTimer(actorID, timerName string, params []byte) error
 }
 
 // DefaultActorManager is to manage one type of actor.
 type DefaultActorManager struct {
-	// factory is the actor factory of specific type of actor
-	factory actor.Factory
+	// factory holds the factoryHolder of the actor factory of specific type of actor and the type its actors are
+	// registered as. It's an atomic.Value as the factory may be replaced while turns run.
+	factory atomic.Value
 
 	// activeActors stores the map actorID -> ActorContainer
 	activeActors sync.Map
 
 	// serializer is the param and response serializer of the actor
 	serializer codec.Codec
+
+	// daprClient is shared by the state providers of all actors of this type, the default client is used if nil
+	daprClient dapr.Client
+
+	// stateProviderFactory creates stateProvider on the first actor activation
+	stateProviderFactory state.StateProviderFactory
+
+	// stateProvider is shared by all actors of this type
+	stateProvider     state.StateProvider
+	stateProviderLock sync.Mutex
+
+	// stateCachePolicy is the state cache policy of the state manager of each actor
+	stateCachePolicy config.StateCachePolicy
+
+	// actorType is the type actors are activated as, the type returned by the actors is used if empty
+	actorType string
+
+	// stateDump is set by EnableStateDump, the state managers of the actors keep snapshots for DumpState if it's set
+	stateDump int32
+
+	// metrics holds the recorderHolder of the recorder of the activations and turns of the actors, as their
+	// registered type. It's an atomic.Value as the recorder may be set while turns run.
+	metrics atomic.Value
 }
 
-func NewDefaultActorManager(serializerType string) (ActorManager, actorErr.ActorErr) {
-	serializer, err := codec.GetActorCodec(serializerType)
+// factoryHolder holds the actor factory of an actor manager, along with the type its actors are registered as,
+// which is the type of the manager or the type of the actors of the factory.
+type factoryHolder struct {
+	factory        actor.Factory
+	registeredType string
+}
+
+// recorderHolder holds a metrics.Recorder, so that recorders of any type can be stored in the same atomic.Value.
+type recorderHolder struct {
+	metrics.Recorder
+}
+
+// ReentrantInvoker is implemented by actor managers running the turns of each actor one at a time, whose method
+// invocations belong to reentrant call chains.
+type ReentrantInvoker interface {
+	// InvokeMethodWithReentrancy invokes the method as InvokeMethodWithCodec does, in the reentrant call chain
+	// @reentrancyID, which enters the actor while the chain holds it. The invocation starts a chain of its own if
+	// @reentrancyID is empty.
+	InvokeMethodWithReentrancy(reentrancyID, actorID, methodName, codecName string, request []byte) ([]byte, error)
+}
+
+// Instrumented is implemented by actor managers recording metrics.
+type Instrumented interface {
+	// SetMetricsRecorder sets the recorder of the metrics of the actor manager to @recorder.
+	SetMetricsRecorder(recorder metrics.Recorder)
+}
+
+// newDefaultDaprClient creates the default Dapr client, it's replaced in tests.
+var newDefaultDaprClient = dapr.NewClient
+
+func NewDefaultActorManager(serializerType string) (ActorManager, error) {
+	return NewDefaultActorManagerWithClient(serializerType, nil)
+}
+
+// NewDefaultActorManagerWithClient creates an actor manager whose actors access their state through @daprClient.
+// If @daprClient is nil, the default client created from DAPR_GRPC_PORT is used on actor activation.
+func NewDefaultActorManagerWithClient(serializerType string, daprClient dapr.Client) (ActorManager, error) {
+	return NewDefaultActorManagerWithStateProvider(serializerType, stateConstant.DefaultStateProviderName, daprClient)
+}
+
+// NewDefaultActorManagerWithStateProvider creates an actor manager whose actors store their state in the state
+// provider registered as @stateProviderName, @daprClient is used by providers backed by Dapr as
+// NewDefaultActorManagerWithClient does.
+func NewDefaultActorManagerWithStateProvider(serializerType, stateProviderName string, daprClient dapr.Client) (ActorManager, error) {
+	return NewDefaultActorManagerWithConfig(config.GetConfigFromOptions(
+		config.WithSerializerName(serializerType),
+		config.WithStateProviderName(stateProviderName),
+	), daprClient)
+}
+
+// NewDefaultActorManagerWithConfig creates an actor manager with the serializer, state provider and state cache
+// policy of @conf, @daprClient is used as NewDefaultActorManagerWithClient does.
+func NewDefaultActorManagerWithConfig(conf *config.ActorConfig, daprClient dapr.Client) (ActorManager, error) {
+	return NewDefaultActorManagerWithType("", conf, daprClient)
+}
+
+// NewDefaultActorManagerWithType creates an actor manager as NewDefaultActorManagerWithConfig does, whose actors
+// are activated as type @actorType, so that one actor implementation can be managed as several types. If @actorType
+// is empty, the type returned by the actors is used.
+func NewDefaultActorManagerWithType(actorType string, conf *config.ActorConfig, daprClient dapr.Client) (ActorManager, error) {
+	serializer, err := codec.GetActorCodec(conf.SerializerType)
 	if err != nil {
-		return nil, actorErr.ErrActorSerializeNoFound
+		return nil, actorErr.New(actorErr.ErrActorSerializeNoFound, err)
+	}
+	stateProviderFactory, err := state.GetStateProviderFactory(conf.StateProviderName)
+	if err != nil {
+		return nil, actorErr.New(actorErr.ErrStateProviderNotFound, err)
+	}
+	m := &DefaultActorManager{
+		serializer:           serializer,
+		daprClient:           daprClient,
+		stateProviderFactory: stateProviderFactory,
+		stateCachePolicy:     conf.StateCachePolicy,
+		actorType:            actorType,
 	}
-	return &DefaultActorManager{
-		serializer: serializer,
-	}, actorErr.Success
+	m.factory.Store(factoryHolder{registeredType: actorType})
+	m.SetMetricsRecorder(nil)
+	return m, nil
 }
 
-// RegisterActorImplFactory registers the action factory f.
+// RegisterActorImplFactory registers the action factory f, it replaces the factory of the actors activated later.
+// If the manager has no actor type, f is called to get the type of its actors, which the managers created with a
+// type by NewDefaultActorManagerWithType don't do.
 func (m *DefaultActorManager) RegisterActorImplFactory(f actor.Factory) {
-	m.factory = f
+	holder := factoryHolder{factory: f, registeredType: m.actorType}
+	if m.actorType == "" && f != nil {
+		holder.registeredType = f().Type()
+	}
+	m.factory.Store(holder)
+}
+
+// getFactory returns the actor factory of the actor manager, nil if it's not registered.
+func (m *DefaultActorManager) getFactory() actor.Factory {
+	return m.factory.Load().(factoryHolder).factory
+}
+
+// registeredType returns the type the actors are registered as.
+func (m *DefaultActorManager) registeredType() string {
+	return m.factory.Load().(factoryHolder).registeredType
+}
+
+func (m *DefaultActorManager) SetMetricsRecorder(recorder metrics.Recorder) {
+	if recorder == nil {
+		recorder = metrics.NopRecorder{}
+	}
+	m.metrics.Store(recorderHolder{recorder})
+}
+
+// recorder returns the recorder of the metrics of the actor manager.
+func (m *DefaultActorManager) recorder() metrics.Recorder {
+	return m.metrics.Load().(recorderHolder).Recorder
+}
+
+// startTurn records the start of a turn of @kind, the returned function records its end with the result @err.
+func (m *DefaultActorManager) startTurn(kind metrics.TurnKind) func(err error) {
+	recorder, actorType := m.recorder(), m.registeredType()
+	recorder.TurnStarted(actorType, kind)
+	start := time.Now()
+	return func(err error) {
+		recorder.TurnEnded(actorType, kind, time.Since(start), err)
+		if actorErr.CodeOf(err) == actorErr.ErrActorMethodSerializeFailed {
+			recorder.SerializationFailed(actorType)
+		}
+	}
+}
+
+// enterTurn enters the turn of the call chain @reentrancyID of the actor in @actorContainer, recording the wait for
+// the turn of another chain of the actor as a queued turn of @kind. The returned function leaves the turn.
+func (m *DefaultActorManager) enterTurn(actorContainer ActorContainer, reentrancyID string, kind metrics.TurnKind) func() {
+	c, ok := actorContainer.(*DefaultActorContainer)
+	if !ok {
+		return func() {}
+	}
+	recorder, actorType := m.recorder(), m.registeredType()
+	c.turns.enter(reentrancyID, func() {
+		recorder.TurnQueued(actorType, kind)
+	}, func() {
+		recorder.TurnDequeued(actorType, kind)
+	})
+	return c.turns.leave
+}
+
+// getDaprClient returns the injected Dapr client, or the default one if none is injected.
+func (m *DefaultActorManager) getDaprClient() (dapr.Client, error) {
+	if m.daprClient != nil {
+		return m.daprClient, nil
+	}
+	daprClient, err := newDefaultDaprClient()
+	if err != nil {
+		return nil, actorErr.New(actorErr.ErrDaprClientNotAvailable, err)
+	}
+	if daprClient == nil {
+		return nil, actorErr.New(actorErr.ErrDaprClientNotAvailable, nil)
+	}
+	return daprClient, nil
+}
+
+// getStateProvider returns the state provider of this actor type, which is created on first call.
+func (m *DefaultActorManager) getStateProvider() (state.StateProvider, error) {
+	m.stateProviderLock.Lock()
+	defer m.stateProviderLock.Unlock()
+	if m.stateProvider != nil {
+		return m.stateProvider, nil
+	}
+	var clientErr error
+	stateProvider, err := m.stateProviderFactory(func() (dapr.Client, error) {
+		daprClient, err := m.getDaprClient()
+		clientErr = err
+		return daprClient, err
+	})
+	if err != nil {
+		if clientErr != nil {
+			return nil, clientErr
+		}
+		return nil, actorErr.New(actorErr.ErrStateProviderNotFound, err)
+	}
+	m.stateProvider = stateProvider
+	return stateProvider, nil
+}
+
+// typeOf returns the type actor @impl is activated as.
+func (m *DefaultActorManager) typeOf(impl actor.Server) string {
+	if m.actorType != "" {
+		return m.actorType
+	}
+	return impl.Type()
 }
 
 // getAndCreateActorContainerIfNotExist will.
-func (m *DefaultActorManager) getAndCreateActorContainerIfNotExist(actorID string) (ActorContainer, actorErr.ActorErr) {
+func (m *DefaultActorManager) getAndCreateActorContainerIfNotExist(actorID string) (ActorContainer, error) {
 	val, ok := m.activeActors.Load(actorID)
 	if !ok {
-		newContainer, aerr := NewDefaultActorContainer(actorID, m.factory(), m.serializer)
-		if aerr != actorErr.Success {
-			return nil, aerr
+		stateProvider, err := m.getStateProvider()
+		if err != nil {
+			return nil, err
+		}
+		impl := m.getFactory()()
+		actorType := m.typeOf(impl)
+		stateManager := state.NewActorStateManagerWithCachePolicy(actorType, actorID, stateProvider, m.stateCachePolicy)
+		newContainer, err := NewDefaultActorContainer(actorID, impl, m.serializer, stateManager)
+		if err != nil {
+			return nil, err
+		}
+		impl.SetScheduler(newDaprScheduler(actorType, actorID, m.serializer, m.getDaprClient, newContainer.HasMethod))
+		var loaded bool
+		if val, loaded = m.activeActors.LoadOrStore(actorID, newContainer); !loaded {
+			m.recorder().ActorActivated(m.registeredType())
+			// the dump is enabled once the container is stored, so that it's enabled by EnableStateDump otherwise
+			if atomic.LoadInt32(&m.stateDump) == 1 {
+				if stateManager, ok := stateManager.(*state.ActorStateManager); ok {
+					stateManager.EnableSnapshots()
+				}
+			}
 		}
-		m.activeActors.Store(actorID, newContainer)
-		val, _ = m.activeActors.Load(actorID)
 	}
-	return val.(ActorContainer), actorErr.Success
+	return val.(ActorContainer), nil
 }
 
 // InvokeMethod to invoke local function by @actorID, @methodName and @request request param.
-func (m *DefaultActorManager) InvokeMethod(actorID, methodName string, request []byte) ([]byte, actorErr.ActorErr) {
-	if m.factory == nil {
-		return nil, actorErr.ErrActorFactoryNotSet
-	}
+func (m *DefaultActorManager) InvokeMethod(actorID, methodName string, request []byte) ([]byte, error) {
+	return m.InvokeMethodWithCodec(actorID, methodName, "", request)
+}
 
-	actorContainer, aerr := m.getAndCreateActorContainerIfNotExist(actorID)
-	if aerr != actorErr.Success {
-		return nil, aerr
-	}
-	returnValue, aerr := actorContainer.Invoke(methodName, request)
-	if aerr != actorErr.Success {
-		return nil, aerr
+func (m *DefaultActorManager) InvokeMethodWithCodec(actorID, methodName, codecName string, request []byte) ([]byte, error) {
+	return m.InvokeMethodWithReentrancy("", actorID, methodName, codecName, request)
+}
+
+func (m *DefaultActorManager) InvokeMethodWithReentrancy(reentrancyID, actorID, methodName, codecName string, request []byte) ([]byte, error) {
+	turnEnded := m.startTurn(metrics.MethodTurn)
+	rspData, err := m.invokeMethod(reentrancyID, actorID, methodName, codecName, request)
+	turnEnded(err)
+	return rspData, err
+}
+
+func (m *DefaultActorManager) invokeMethod(reentrancyID, actorID, methodName, codecName string, request []byte) ([]byte, error) {
+	if m.getFactory() == nil {
+		return nil, withContext(actorErr.ErrActorFactoryNotSet, actorID, methodName)
 	}
-	if len(returnValue) == 1 {
-		return nil, actorErr.Success
+	serializer := m.serializer
+	if codecName != "" {
+		var err error
+		if serializer, err = codec.GetActorCodec(codecName); err != nil {
+			return nil, withContext(actorErr.New(actorErr.ErrActorSerializeNoFound, err), actorID, methodName)
+		}
 	}
 
-	var (
-		retErr interface{}
-		replyv reflect.Value
-	)
-
-	if len(returnValue) == 2 {
-		replyv = returnValue[0]
-		retErr = returnValue[1].Interface()
+	actorContainer, err := m.getAndCreateActorContainerIfNotExist(actorID)
+	if err != nil {
+		return nil, withContext(err, actorID, methodName)
+	}
+	defer m.enterTurn(actorContainer, reentrancyID, metrics.MethodTurn)()
+	rspData, err := actorContainer.InvokeWithCodec(methodName, serializer, request)
+	if perrors.Is(err, actorErr.ErrActorScheduleDone) {
+		// only reminder and timer callbacks can be done
+		err = actorErr.New(actorErr.ErrActorInvokeFailed, perrors.Unwrap(err))
 	}
+	if err = m.endTurn(actorContainer, err); err != nil {
+		return nil, withContext(err, actorID, methodName)
+	}
+	return rspData, nil
+}
 
-	if retErr != nil {
-		return nil, actorErr.ErrActorInvokeFailed
+// endTurn ends the turn of the actor in @actorContainer which results in @err, the invocation of a method, reminder
+// or timer is a turn of the actor. Its state changes are saved if it succeeds, and discarded otherwise.
+func (m *DefaultActorManager) endTurn(actorContainer ActorContainer, err error) error {
+	if err != nil {
+		actorContainer.GetActor().DiscardState()
+		return err
 	}
-	rspData, err := m.serializer.Marshal(replyv.Interface())
+	start := time.Now()
+	err = actorContainer.GetActor().SaveState()
+	m.recorder().StateSaved(m.registeredType(), time.Since(start), err)
 	if err != nil {
-		return nil, actorErr.ErrActorMethodSerializeFailed
+		actorContainer.GetActor().DiscardState()
+		return actorErr.New(actorErr.ErrSaveStateFailed, err)
 	}
-	if err := actorContainer.GetActor().SaveState(); err != nil {
-		return nil, actorErr.ErrSaveStateFailed
+	return nil
+}
+
+// withContext sets the actor id and method of the actor failure @err, or wraps @err as an actor failure, of the
+// invocation failure code if @err has no code. The actor type is set by the runtime, which routes invocations by type.
+func withContext(err error, actorID, method string) error {
+	var actorError *actorErr.Error
+	switch e := err.(type) {
+	case *actorErr.Error:
+		actorError = e
+	case actorErr.ActorErr:
+		actorError = actorErr.New(e, nil)
+	default:
+		actorError = actorErr.New(actorErr.ErrActorInvokeFailed, err)
 	}
-	return rspData, actorErr.Success
+	actorError.ActorID = actorID
+	actorError.Method = method
+	return actorError
 }
 
 // DetectiveActor removes actor from actor manager.
-func (m *DefaultActorManager) DetectiveActor(actorID string) actorErr.ActorErr {
-	_, ok := m.activeActors.Load(actorID)
-	if !ok {
-		return actorErr.ErrActorIDNotFound
+func (m *DefaultActorManager) DetectiveActor(actorID string) error {
+	if _, ok := m.activeActors.LoadAndDelete(actorID); !ok {
+		return actorErr.New(actorErr.ErrActorIDNotFound, nil).WithActor("", actorID)
 	}
-	m.activeActors.Delete(actorID)
-	return actorErr.Success
+	m.recorder().ActorDeactivated(m.registeredType())
+	return nil
 }
 
 // InvokeReminder invoke reminder function with given params.
-func (m *DefaultActorManager) InvokeReminder(actorID, reminderName string, params []byte) actorErr.ActorErr {
-	if m.factory == nil {
+func (m *DefaultActorManager) InvokeReminder(actorID, reminderName string, params []byte) error {
+	turnEnded := m.startTurn(metrics.ReminderTurn)
+	err := m.invokeReminder(actorID, reminderName, params)
+	turnEnded(err)
+	if err != nil {
+		return withContext(err, actorID, reminderName)
+	}
+	return nil
+}
+
+func (m *DefaultActorManager) invokeReminder(actorID, reminderName string, params []byte) error {
+	if m.getFactory() == nil {
 		return actorErr.ErrActorFactoryNotSet
 	}
 	reminderParams := &api.ActorReminderParams{}
 	if err := json.Unmarshal(params, reminderParams); err != nil {
-		log.Printf("failed to unmarshal reminder param, err: %v ", err)
-		return actorErr.ErrRemindersParamsInvalid
+		return actorErr.New(actorErr.ErrRemindersParamsInvalid, err)
 	}
-	actorContainer, aerr := m.getAndCreateActorContainerIfNotExist(actorID)
-	if aerr != actorErr.Success {
-		return aerr
+	actorContainer, err := m.getAndCreateActorContainerIfNotExist(actorID)
+	if err != nil {
+		return err
 	}
+	defer m.enterTurn(actorContainer, "", metrics.ReminderTurn)()
 
-	targetActor, ok := actorContainer.GetActor().(actor.ReminderCallee)
-	if !ok {
+	switch targetActor := actorContainer.GetActor().(type) {
+	case actor.ReminderHandler:
+		schedule, err := actor.ParseSchedule(reminderParams.DueTime, reminderParams.Period, reminderParams.TTL)
+		if err != nil {
+			return actorErr.New(actorErr.ErrRemindersParamsInvalid, err)
+		}
+		reminder := actor.NewReminder(reminderName, schedule, reminderParams.Data, m.serializer)
+		err = targetActor.HandleReminder(context.Background(), reminder)
+		done := perrors.Is(err, actor.ErrScheduleDone)
+		if err != nil && !done {
+			return m.endTurn(actorContainer, err)
+		}
+		if err := m.endTurn(actorContainer, nil); err != nil || !done {
+			return err
+		}
+		return m.unregisterDone(m.typeOf(actorContainer.GetActor()), actorID, func(ctx context.Context, scheduler actor.Scheduler) error {
+			return scheduler.UnregisterReminder(ctx, reminderName)
+		})
+	case actor.ReminderCallee:
+		targetActor.ReminderCall(reminderName, reminderParams.Data, reminderParams.DueTime, reminderParams.Period)
+		return m.endTurn(actorContainer, nil)
+	default:
 		return actorErr.ErrReminderFuncUndefined
 	}
-	targetActor.ReminderCall(reminderName, reminderParams.Data, reminderParams.DueTime, reminderParams.Period)
-	return actorErr.Success
 }
 
 // InvokeTimer invoke timer callback function with given  params.
-func (m *DefaultActorManager) InvokeTimer(actorID, timerName string, params []byte) actorErr.ActorErr {
-	if m.factory == nil {
+func (m *DefaultActorManager) InvokeTimer(actorID, timerName string, params []byte) error {
+	turnEnded := m.startTurn(metrics.TimerTurn)
+	err := m.invokeTimer(actorID, timerName, params)
+	turnEnded(err)
+	if err != nil {
+		return withContext(err, actorID, timerName)
+	}
+	return nil
+}
+
+func (m *DefaultActorManager) invokeTimer(actorID, timerName string, params []byte) error {
+	if m.getFactory() == nil {
 		return actorErr.ErrActorFactoryNotSet
 	}
 	timerParams := &api.ActorTimerParam{}
 	if err := json.Unmarshal(params, timerParams); err != nil {
-		log.Printf("failed to unmarshal reminder param, err: %v ", err)
-		return actorErr.ErrTimerParamsInvalid
+		return actorErr.New(actorErr.ErrTimerParamsInvalid, err)
 	}
-	actorContainer, aerr := m.getAndCreateActorContainerIfNotExist(actorID)
-	if aerr != actorErr.Success {
-		return aerr
+	actorContainer, err := m.getAndCreateActorContainerIfNotExist(actorID)
+	if err != nil {
+		return err
 	}
-	_, aerr = actorContainer.Invoke(timerParams.CallBack, timerParams.Data)
-	return aerr
+	defer m.enterTurn(actorContainer, "", metrics.TimerTurn)()
+	_, err = actorContainer.Invoke(timerParams.CallBack, timerParams.Data)
+	done := perrors.Is(err, actorErr.ErrActorScheduleDone)
+	if done {
+		err = nil
+	}
+	if err = m.endTurn(actorContainer, err); err != nil {
+		return err
+	}
+	if !done {
+		return nil
+	}
+	return m.unregisterDone(m.typeOf(actorContainer.GetActor()), actorID, func(ctx context.Context, scheduler actor.Scheduler) error {
+		return scheduler.UnregisterTimer(ctx, timerName)
+	})
+}
+
+// unregisterDone unregisters the reminder or timer of actor @actorID whose callback reported it's done.
+func (m *DefaultActorManager) unregisterDone(actorType, actorID string, unregister func(ctx context.Context, scheduler actor.Scheduler) error) error {
+	scheduler := newDaprScheduler(actorType, actorID, m.serializer, m.getDaprClient, nil)
+	if err := unregister(context.Background(), scheduler); err != nil {
+		return actorErr.New(actorErr.ErrActorInvokeFailed, perrors.Wrap(err, "failed to unregister done reminder or timer"))
+	}
+	return nil
 }
 
 func getAbsctractMethodMap(rcvr interface{}) (map[string]*MethodType, error) {
@@ -193,11 +512,15 @@ func suitableMethods(typ reflect.Type) map[string]*MethodType {
 	methods := make(map[string]*MethodType)
 	for m := 0; m < typ.NumMethod(); m++ {
 		method := typ.Method(m)
-		if mt, err := suiteMethod(method); mt != nil && err != nil {
+		if _, ok := frameworkMethods[method.Name]; ok {
+			continue
+		}
+		mt, err := suiteMethod(method)
+		if err != nil {
 			log.Printf("method %s is illegal, err = %s, just skip it", method.Name, err)
-		} else {
-			methods[method.Name] = mt
+			continue
 		}
+		methods[method.Name] = mt
 	}
 	return methods
 }
@@ -255,7 +578,29 @@ func suiteMethod(method reflect.Method) (*MethodType, error) {
 	return &MethodType{method: method, argsType: argsType, replyType: replyType, ctxType: ctxType}, nil
 }
 
-var typeOfError = reflect.TypeOf((*error)(nil)).Elem()
+var (
+	typeOfError = reflect.TypeOf((*error)(nil)).Elem()
+
+	// frameworkMethods are methods of actor.Server, actor.ServerImplBase and callback interfaces, which are
+	// never invoked as user defined actor methods.
+	frameworkMethods = methodNames(
+		reflect.TypeOf((*actor.Server)(nil)).Elem(),
+		reflect.TypeOf((*actor.ReminderCallee)(nil)).Elem(),
+		reflect.TypeOf((*actor.ReminderHandler)(nil)).Elem(),
+		reflect.TypeOf((*actor.Dispatcher)(nil)).Elem(),
+		reflect.TypeOf(&actor.ServerImplBase{}),
+	)
+)
+
+func methodNames(types ...reflect.Type) map[string]struct{} {
+	names := make(map[string]struct{})
+	for _, typ := range types {
+		for i := 0; i < typ.NumMethod(); i++ {
+			names[typ.Method(i).Name] = struct{}{}
+		}
+	}
+	return names
+}
 
 func isExportedOrBuiltinType(t reflect.Type) bool {
 	for t.Kind() == reflect.Ptr {
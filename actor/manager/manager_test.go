@@ -1,86 +1,98 @@
 package manager
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/dapr/go-sdk/actor"
 	"github.com/dapr/go-sdk/actor/api"
+	"github.com/dapr/go-sdk/actor/config"
 	actorErr "github.com/dapr/go-sdk/actor/error"
+	"github.com/dapr/go-sdk/actor/metrics"
 	"github.com/dapr/go-sdk/actor/mock"
+	"github.com/dapr/go-sdk/actor/state"
+	stateConstant "github.com/dapr/go-sdk/actor/state/constant"
+	dapr "github.com/dapr/go-sdk/client"
 )
 
 func TestNewDefaultActorManager(t *testing.T) {
 	mng, err := NewDefaultActorManager("json")
 	assert.NotNil(t, mng)
-	assert.Equal(t, actorErr.Success, err)
+	assert.NoError(t, err)
 
 	mng, err = NewDefaultActorManager("badSerializerType")
 	assert.Nil(t, mng)
-	assert.Equal(t, actorErr.ErrActorSerializeNoFound, err)
+	assert.ErrorIs(t, err, actorErr.ErrActorSerializeNoFound)
 }
 
 func TestRegisterActorImplFactory(t *testing.T) {
-	mng, err := NewDefaultActorManager("json")
+	mng, err := NewDefaultActorManagerWithClient("json", mock.NewDaprClient())
 	assert.NotNil(t, mng)
-	assert.Equal(t, actorErr.Success, err)
-	assert.Nil(t, mng.(*DefaultActorManager).factory)
+	assert.NoError(t, err)
+	assert.Nil(t, mng.(*DefaultActorManager).getFactory())
 	mng.RegisterActorImplFactory(mock.ActorImplFactory)
-	assert.NotNil(t, mng.(*DefaultActorManager).factory)
+	assert.NotNil(t, mng.(*DefaultActorManager).getFactory())
 }
 
 func TestInvokeMethod(t *testing.T) {
-	mng, err := NewDefaultActorManager("json")
+	mng, err := NewDefaultActorManagerWithClient("json", mock.NewDaprClient())
 	assert.NotNil(t, mng)
-	assert.Equal(t, actorErr.Success, err)
-	assert.Nil(t, mng.(*DefaultActorManager).factory)
+	assert.NoError(t, err)
+	assert.Nil(t, mng.(*DefaultActorManager).getFactory())
 
 	data, err := mng.InvokeMethod("testActorID", "testMethodName", []byte(`"hello"`))
 	assert.Nil(t, data)
-	assert.Equal(t, actorErr.ErrActorFactoryNotSet, err)
+	assert.ErrorIs(t, err, actorErr.ErrActorFactoryNotSet)
 
 	mng.RegisterActorImplFactory(mock.ActorImplFactory)
-	assert.NotNil(t, mng.(*DefaultActorManager).factory)
+	assert.NotNil(t, mng.(*DefaultActorManager).getFactory())
 	data, err = mng.InvokeMethod("testActorID", "mockMethod", []byte(`"hello"`))
 	assert.Nil(t, data)
-	assert.Equal(t, actorErr.ErrActorMethodNoFound, err)
+	assert.ErrorIs(t, err, actorErr.ErrActorMethodNoFound)
 
 	data, err = mng.InvokeMethod("testActorID", "Invoke", []byte(`"hello"`))
 	assert.Equal(t, data, []byte(`"hello"`))
-	assert.Equal(t, actorErr.Success, err)
+	assert.NoError(t, err)
 }
 
 func TestDetectiveActor(t *testing.T) {
-	mng, err := NewDefaultActorManager("json")
+	mng, err := NewDefaultActorManagerWithClient("json", mock.NewDaprClient())
 	assert.NotNil(t, mng)
-	assert.Equal(t, actorErr.Success, err)
-	assert.Nil(t, mng.(*DefaultActorManager).factory)
+	assert.NoError(t, err)
+	assert.Nil(t, mng.(*DefaultActorManager).getFactory())
 
 	err = mng.DetectiveActor("testActorID")
-	assert.Equal(t, actorErr.ErrActorIDNotFound, err)
+	assert.ErrorIs(t, err, actorErr.ErrActorIDNotFound)
 
 	mng.RegisterActorImplFactory(mock.ActorImplFactory)
-	assert.NotNil(t, mng.(*DefaultActorManager).factory)
+	assert.NotNil(t, mng.(*DefaultActorManager).getFactory())
 	mng.InvokeMethod("testActorID", "Invoke", []byte(`"hello"`))
 
 	err = mng.DetectiveActor("testActorID")
-	assert.Equal(t, actorErr.Success, err)
+	assert.NoError(t, err)
 }
 
 func TestInvokeReminder(t *testing.T) {
-	mng, err := NewDefaultActorManager("json")
+	mng, err := NewDefaultActorManagerWithClient("json", mock.NewDaprClient())
 	assert.NotNil(t, mng)
-	assert.Equal(t, actorErr.Success, err)
-	assert.Nil(t, mng.(*DefaultActorManager).factory)
+	assert.NoError(t, err)
+	assert.Nil(t, mng.(*DefaultActorManager).getFactory())
 
 	err = mng.InvokeReminder("testActorID", "testReminderName", []byte(`"hello"`))
-	assert.Equal(t, actorErr.ErrActorFactoryNotSet, err)
+	assert.ErrorIs(t, err, actorErr.ErrActorFactoryNotSet)
 
 	mng.RegisterActorImplFactory(mock.ActorImplFactory)
-	assert.NotNil(t, mng.(*DefaultActorManager).factory)
+	assert.NotNil(t, mng.(*DefaultActorManager).getFactory())
 	err = mng.InvokeReminder("testActorID", "testReminderName", []byte(`"hello"`))
-	assert.Equal(t, actorErr.ErrRemindersParamsInvalid, err)
+	assert.ErrorIs(t, err, actorErr.ErrRemindersParamsInvalid)
 
 	reminderParam, _ := json.Marshal(&api.ActorReminderParams{
 		Data:    []byte("hello"),
@@ -88,22 +100,22 @@ func TestInvokeReminder(t *testing.T) {
 		Period:  "6s",
 	})
 	err = mng.InvokeReminder("testActorID", "testReminderName", reminderParam)
-	assert.Equal(t, actorErr.Success, err)
+	assert.NoError(t, err)
 }
 
 func TestInvokeTimer(t *testing.T) {
-	mng, err := NewDefaultActorManager("json")
+	mng, err := NewDefaultActorManagerWithClient("json", mock.NewDaprClient())
 	assert.NotNil(t, mng)
-	assert.Equal(t, actorErr.Success, err)
-	assert.Nil(t, mng.(*DefaultActorManager).factory)
+	assert.NoError(t, err)
+	assert.Nil(t, mng.(*DefaultActorManager).getFactory())
 
 	err = mng.InvokeTimer("testActorID", "testTimerName", []byte(`"hello"`))
-	assert.Equal(t, actorErr.ErrActorFactoryNotSet, err)
+	assert.ErrorIs(t, err, actorErr.ErrActorFactoryNotSet)
 
 	mng.RegisterActorImplFactory(mock.ActorImplFactory)
-	assert.NotNil(t, mng.(*DefaultActorManager).factory)
+	assert.NotNil(t, mng.(*DefaultActorManager).getFactory())
 	err = mng.InvokeTimer("testActorID", "testTimerName", []byte(`"hello"`))
-	assert.Equal(t, actorErr.ErrTimerParamsInvalid, err)
+	assert.ErrorIs(t, err, actorErr.ErrTimerParamsInvalid)
 
 	timerParam, _ := json.Marshal(&api.ActorTimerParam{
 		Data:     []byte("hello"),
@@ -112,7 +124,7 @@ func TestInvokeTimer(t *testing.T) {
 		CallBack: "Invoke",
 	})
 	err = mng.InvokeTimer("testActorID", "testTimerName", timerParam)
-	assert.Equal(t, actorErr.ErrActorMethodSerializeFailed, err)
+	assert.ErrorIs(t, err, actorErr.ErrActorMethodSerializeFailed)
 
 	timerParam, _ = json.Marshal(&api.ActorTimerParam{
 		Data:     []byte("hello"),
@@ -121,7 +133,7 @@ func TestInvokeTimer(t *testing.T) {
 		CallBack: "NoSuchMethod",
 	})
 	err = mng.InvokeTimer("testActorID", "testTimerName", timerParam)
-	assert.Equal(t, actorErr.ErrActorMethodNoFound, err)
+	assert.ErrorIs(t, err, actorErr.ErrActorMethodNoFound)
 
 	timerParam, _ = json.Marshal(&api.ActorTimerParam{
 		Data:     []byte(`"hello"`),
@@ -130,5 +142,444 @@ func TestInvokeTimer(t *testing.T) {
 		CallBack: "Invoke",
 	})
 	err = mng.InvokeTimer("testActorID", "testTimerName", timerParam)
-	assert.Equal(t, actorErr.Success, err)
+	assert.NoError(t, err)
+}
+
+func TestInvokeMethodWithMultipleArgs(t *testing.T) {
+	mng, err := NewDefaultActorManagerWithClient("json", mock.NewDaprClient())
+	assert.NotNil(t, mng)
+	assert.NoError(t, err)
+	mng.RegisterActorImplFactory(mock.ActorImplFactory)
+
+	data, err := mng.InvokeMethod("testActorID", "Add", []byte(`[9007199254740993, 1, "sum"]`))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`"sum:9007199254740994"`), data)
+
+	data, err = mng.InvokeMethod("testActorID", "Add", []byte(`[1, 2]`))
+	assert.Nil(t, data)
+	assert.ErrorIs(t, err, actorErr.ErrActorMethodSerializeFailed)
+
+	data, err = mng.InvokeMethod("testActorID", "Ping", nil)
+	assert.Nil(t, data)
+	assert.NoError(t, err)
+
+	data, err = mng.InvokeMethod("testActorID", "ReminderCall", nil)
+	assert.Nil(t, data)
+	assert.ErrorIs(t, err, actorErr.ErrActorMethodNoFound)
+}
+
+func TestInvokeMethodWithMultipleArgsYaml(t *testing.T) {
+	mng, err := NewDefaultActorManagerWithClient("yaml", mock.NewDaprClient())
+	assert.NotNil(t, mng)
+	assert.NoError(t, err)
+	mng.RegisterActorImplFactory(mock.ActorImplFactory)
+
+	data, err := mng.InvokeMethod("testActorID", "Add", []byte("- 1\n- 2\n- sum\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("sum:3\n"), data)
+}
+
+func TestInvokeMethodWithCodec(t *testing.T) {
+	mng, err := NewDefaultActorManagerWithClient("json", mock.NewDaprClient())
+	assert.NoError(t, err)
+	mng.RegisterActorImplFactory(mock.ActorImplFactory)
+
+	// the codec declared by the caller overrides the serializer of the manager for both the arguments and the reply
+	data, err := mng.InvokeMethodWithCodec("testActorID", "Add", "yaml", []byte("- 1\n- 2\n- sum\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("sum:3\n"), data)
+
+	data, err = mng.InvokeMethodWithCodec("testActorID", "Add", "", []byte(`[1, 2, "sum"]`))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`"sum:3"`), data)
+
+	_, err = mng.InvokeMethodWithCodec("testActorID", "Add", "unknown", []byte(`[1, 2, "sum"]`))
+	assert.ErrorIs(t, err, actorErr.ErrActorSerializeNoFound)
+}
+
+func TestActivateWithoutDaprClient(t *testing.T) {
+	defaultDaprClient := newDefaultDaprClient
+	defer func() {
+		newDefaultDaprClient = defaultDaprClient
+	}()
+	newDefaultDaprClient = func() (dapr.Client, error) {
+		return nil, errors.New("connection refused")
+	}
+
+	mng, err := NewDefaultActorManager("json")
+	assert.NotNil(t, mng)
+	assert.NoError(t, err)
+	mng.RegisterActorImplFactory(mock.ActorImplFactory)
+
+	data, err := mng.InvokeMethod("testActorID", "Invoke", []byte(`"hello"`))
+	assert.Nil(t, data)
+	assert.ErrorIs(t, err, actorErr.ErrDaprClientNotAvailable)
+
+	// the default client is used if none is injected
+	newDefaultDaprClient = func() (dapr.Client, error) {
+		return mock.NewDaprClient(), nil
+	}
+	data, err = mng.InvokeMethod("testActorID", "Invoke", []byte(`"hello"`))
+	assert.Equal(t, []byte(`"hello"`), data)
+	assert.NoError(t, err)
+}
+
+func TestStateProvider(t *testing.T) {
+	mng, err := NewDefaultActorManagerWithStateProvider("json", "badStateProviderName", nil)
+	assert.Nil(t, mng)
+	assert.ErrorIs(t, err, actorErr.ErrStateProviderNotFound)
+
+	defaultDaprClient := newDefaultDaprClient
+	defer func() {
+		newDefaultDaprClient = defaultDaprClient
+	}()
+	newDefaultDaprClient = func() (dapr.Client, error) {
+		return nil, errors.New("connection refused")
+	}
+
+	// the in-memory state provider doesn't need a Dapr client, and is shared by the actors of the type
+	mng, err = NewDefaultActorManagerWithStateProvider("json", stateConstant.MemoryStateProviderName, nil)
+	assert.NotNil(t, mng)
+	assert.NoError(t, err)
+	mng.RegisterActorImplFactory(mock.ActorImplFactory)
+	data, err := mng.InvokeMethod("testActorID", "Invoke", []byte(`"hello"`))
+	assert.Equal(t, []byte(`"hello"`), data)
+	assert.NoError(t, err)
+	_, err = mng.InvokeMethod("otherActorID", "Invoke", []byte(`"hello"`))
+	assert.NoError(t, err)
+
+	stateProvider := mng.(*DefaultActorManager).stateProvider
+	assert.IsType(t, &state.MemoryStateProvider{}, stateProvider)
+	assert.Equal(t, 2, countActors(mng.(*DefaultActorManager)))
+}
+
+func countActors(mng *DefaultActorManager) int {
+	count := 0
+	mng.activeActors.Range(func(key, value interface{}) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+type CounterActor struct {
+	actor.ServerImplBase
+}
+
+func (a *CounterActor) Type() string {
+	return "counterActorType"
+}
+
+// Add adds @delta to the counter, and fails after changing the state if the counter becomes negative.
+func (a *CounterActor) Add(ctx context.Context, delta int) (int, error) {
+	count := 0
+	if err := a.GetStateManager().GetOrDefault("count", &count, 0); err != nil {
+		return 0, err
+	}
+	count += delta
+	if err := a.GetStateManager().Set("count", count); err != nil {
+		return 0, err
+	}
+	if count < 0 {
+		return 0, errors.New("negative counter")
+	}
+	return count, nil
+}
+
+func TestInvokeMethodTurn(t *testing.T) {
+	mng, aerr := NewDefaultActorManagerWithConfig(config.GetConfigFromOptions(
+		config.WithStateProviderName(stateConstant.MemoryStateProviderName),
+	), nil)
+	assert.NoError(t, aerr)
+	mng.RegisterActorImplFactory(func() actor.Server {
+		return &CounterActor{}
+	})
+
+	data, aerr := mng.InvokeMethod("testActorID", "Add", []byte(`1`))
+	assert.NoError(t, aerr)
+	assert.Equal(t, []byte(`1`), data)
+
+	// the changes of the failed invocation are discarded, rather than saved by the next one
+	_, aerr = mng.InvokeMethod("testActorID", "Add", []byte(`-5`))
+	assert.ErrorIs(t, aerr, actorErr.ErrActorInvokeFailed)
+	data, aerr = mng.InvokeMethod("testActorID", "Add", []byte(`0`))
+	assert.NoError(t, aerr)
+	assert.Equal(t, []byte(`1`), data)
+
+	count := 0
+	stateProvider := mng.(*DefaultActorManager).stateProvider
+	assert.NoError(t, stateProvider.Load("counterActorType", "testActorID", "count", &count))
+	assert.Equal(t, 1, count)
+}
+
+func TestInvokeTimerTurn(t *testing.T) {
+	mng, aerr := NewDefaultActorManagerWithConfig(config.GetConfigFromOptions(
+		config.WithStateProviderName(stateConstant.MemoryStateProviderName),
+	), nil)
+	assert.NoError(t, aerr)
+	mng.RegisterActorImplFactory(func() actor.Server {
+		return &CounterActor{}
+	})
+
+	timerParam := func(delta string) []byte {
+		param, _ := json.Marshal(&api.ActorTimerParam{
+			Data:     []byte(delta),
+			DueTime:  "0s",
+			Period:   "1s",
+			CallBack: "Add",
+		})
+		return param
+	}
+	// the timer callback is a turn of the actor, its state changes are saved if it succeeds
+	assert.NoError(t, mng.InvokeTimer("testActorID", "timer", timerParam(`2`)))
+	count := 0
+	stateProvider := mng.(*DefaultActorManager).stateProvider
+	assert.NoError(t, stateProvider.Load("counterActorType", "testActorID", "count", &count))
+	assert.Equal(t, 2, count)
+
+	// and the failure of the callback is reported, with its changes discarded
+	assert.ErrorIs(t, mng.InvokeTimer("testActorID", "timer", timerParam(`-5`)), actorErr.ErrActorInvokeFailed)
+	assert.ErrorIs(t, mng.InvokeTimer("testActorID", "timer", timerParam(`"two"`)), actorErr.ErrActorMethodSerializeFailed)
+	data, aerr := mng.InvokeMethod("testActorID", "Add", []byte(`0`))
+	assert.NoError(t, aerr)
+	assert.Equal(t, []byte(`2`), data)
+}
+
+func TestActorManagerWithType(t *testing.T) {
+	mng, err := NewDefaultActorManagerWithType("registeredActorType", config.GetConfigFromOptions(
+		config.WithStateProviderName(stateConstant.MemoryStateProviderName),
+	), nil)
+	assert.NoError(t, err)
+	mng.RegisterActorImplFactory(func() actor.Server {
+		return &CounterActor{}
+	})
+
+	_, err = mng.InvokeMethod("testActorID", "Add", []byte(`3`))
+	assert.NoError(t, err)
+	// the state is stored as the registered type, rather than the type the actor returns
+	count := 0
+	stateProvider := mng.(*DefaultActorManager).stateProvider
+	assert.NoError(t, stateProvider.Load("registeredActorType", "testActorID", "count", &count))
+	assert.Equal(t, 3, count)
+	assert.Error(t, stateProvider.Load("counterActorType", "testActorID", "count", &count))
+}
+
+// persistedStateClient answers the actor states it holds.
+type persistedStateClient struct {
+	dapr.Client
+	states map[string][]byte
+}
+
+func (c *persistedStateClient) GetActorState(ctx context.Context, in *dapr.GetActorStateRequest) (*dapr.GetActorStateResponse, error) {
+	return &dapr.GetActorStateResponse{Data: c.states[in.ActorType+"/"+in.ActorID+"/"+in.KeyName]}, nil
+}
+
+func TestActorInspector(t *testing.T) {
+	daprClient := &persistedStateClient{states: map[string][]byte{"counterActorType/testActorID/count": []byte(`3`)}}
+	mng, err := NewDefaultActorManagerWithConfig(config.GetConfigFromOptions(
+		config.WithStateProviderName(stateConstant.MemoryStateProviderName),
+	), daprClient)
+	assert.NoError(t, err)
+	mng.RegisterActorImplFactory(func() actor.Server {
+		return &CounterActor{}
+	})
+	inspector := mng.(ActorInspector)
+	assert.Empty(t, inspector.ActiveActorIDs())
+	inspector.EnableStateDump()
+
+	_, err = mng.InvokeMethod("testActorID", "Add", []byte(`3`))
+	assert.NoError(t, err)
+	_, err = mng.InvokeMethod("anotherActorID", "Add", []byte(`1`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"anotherActorID", "testActorID"}, inspector.ActiveActorIDs())
+
+	states, err := inspector.DumpState("testActorID")
+	assert.NoError(t, err)
+	assert.Equal(t, []state.CachedState{{Name: "count", Kind: state.Update, Value: json.RawMessage(`3`)}}, states)
+	_, err = inspector.DumpState("unknownActorID")
+	assert.ErrorIs(t, err, actorErr.ErrActorIDNotFound)
+
+	data, err := inspector.GetPersistedState("testActorID", "count")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`3`), data)
+
+	assert.NoError(t, mng.DetectiveActor("testActorID"))
+	assert.Equal(t, []string{"anotherActorID"}, inspector.ActiveActorIDs())
+}
+
+// countingRecorder counts the metrics recorded per kind of record.
+type countingRecorder struct {
+	metrics.NopRecorder
+	counts map[string]int
+}
+
+func (c *countingRecorder) ActorActivated(actorType string) {
+	c.counts["activated:"+actorType]++
+}
+
+func (c *countingRecorder) ActorDeactivated(actorType string) {
+	c.counts["deactivated:"+actorType]++
+}
+
+func (c *countingRecorder) TurnEnded(actorType string, kind metrics.TurnKind, elapsed time.Duration, err error) {
+	c.counts[fmt.Sprintf("turn:%s:%s:%t", actorType, kind, err == nil)]++
+}
+
+func (c *countingRecorder) StateSaved(actorType string, elapsed time.Duration, err error) {
+	c.counts["saved:"+actorType]++
+}
+
+func (c *countingRecorder) SerializationFailed(actorType string) {
+	c.counts["serialization:"+actorType]++
+}
+
+func TestActorManagerMetrics(t *testing.T) {
+	recorder := &countingRecorder{counts: make(map[string]int)}
+	mng, err := NewDefaultActorManagerWithConfig(config.GetConfigFromOptions(
+		config.WithStateProviderName(stateConstant.MemoryStateProviderName),
+	), nil)
+	assert.NoError(t, err)
+	mng.RegisterActorImplFactory(func() actor.Server {
+		return &CounterActor{}
+	})
+	mng.(Instrumented).SetMetricsRecorder(recorder)
+
+	_, err = mng.InvokeMethod("testActorID", "Add", []byte(`1`))
+	assert.NoError(t, err)
+	_, err = mng.InvokeMethod("testActorID", "Add", []byte(`"one"`))
+	assert.ErrorIs(t, err, actorErr.ErrActorMethodSerializeFailed)
+	assert.NoError(t, mng.InvokeTimer("testActorID", "timer", []byte(`{"callback":"Add","data":"Mg=="}`)))
+	assert.NoError(t, mng.DetectiveActor("testActorID"))
+
+	assert.Equal(t, map[string]int{
+		"activated:counterActorType":         1,
+		"deactivated:counterActorType":       1,
+		"turn:counterActorType:method:true":  1,
+		"turn:counterActorType:method:false": 1,
+		"turn:counterActorType:timer:true":   1,
+		// the state is saved by each successful turn
+		"saved:counterActorType":         2,
+		"serialization:counterActorType": 1,
+	}, recorder.counts)
+}
+
+// BlockingActor blocks in Block until release is closed.
+type BlockingActor struct {
+	actor.ServerImplBase
+	started chan struct{}
+	release chan struct{}
+}
+
+func (a *BlockingActor) Type() string {
+	return "blockingActorType"
+}
+
+func (a *BlockingActor) Block(ctx context.Context) error {
+	a.started <- struct{}{}
+	<-a.release
+	return nil
+}
+
+func (a *BlockingActor) Ping(ctx context.Context) error {
+	return nil
+}
+
+// queueRecorder signals the turns queued, and counts the turns dequeued.
+type queueRecorder struct {
+	metrics.NopRecorder
+	queued   chan metrics.TurnKind
+	dequeued int32
+}
+
+func (q *queueRecorder) TurnQueued(actorType string, kind metrics.TurnKind) {
+	q.queued <- kind
+}
+
+func (q *queueRecorder) TurnDequeued(actorType string, kind metrics.TurnKind) {
+	atomic.AddInt32(&q.dequeued, 1)
+}
+
+func TestInvokeMethodWithReentrancy(t *testing.T) {
+	mng, err := NewDefaultActorManagerWithConfig(config.GetConfigFromOptions(
+		config.WithStateProviderName(stateConstant.MemoryStateProviderName),
+	), nil)
+	assert.NoError(t, err)
+	blocking := &BlockingActor{started: make(chan struct{}), release: make(chan struct{})}
+	mng.RegisterActorImplFactory(func() actor.Server {
+		return &BlockingActor{started: blocking.started, release: blocking.release}
+	})
+	recorder := &queueRecorder{queued: make(chan metrics.TurnKind, 1)}
+	mng.(Instrumented).SetMetricsRecorder(recorder)
+	invoker := mng.(ReentrantInvoker)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, err := invoker.InvokeMethodWithReentrancy("chain-1", "testActorID", "Block", "", nil)
+		assert.NoError(t, err)
+	}()
+	<-blocking.started
+
+	// the call of the chain holding the actor enters it, the others wait for the chain to end
+	_, err = invoker.InvokeMethodWithReentrancy("chain-1", "testActorID", "Ping", "", nil)
+	assert.NoError(t, err)
+	go func() {
+		defer wg.Done()
+		_, err := mng.InvokeMethod("testActorID", "Ping", nil)
+		assert.NoError(t, err)
+	}()
+	assert.Equal(t, metrics.MethodTurn, <-recorder.queued)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&recorder.dequeued))
+
+	close(blocking.release)
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&recorder.dequeued))
+}
+
+func TestSetMetricsRecorderWhileTurnsRun(t *testing.T) {
+	mng, err := NewDefaultActorManagerWithConfig(config.GetConfigFromOptions(
+		config.WithStateProviderName(stateConstant.MemoryStateProviderName),
+	), nil)
+	assert.NoError(t, err)
+	mng.RegisterActorImplFactory(func() actor.Server {
+		return &CounterActor{}
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := mng.InvokeMethod(fmt.Sprintf("actor-%d", i), "Add", []byte(`1`))
+			assert.NoError(t, err)
+		}(i)
+	}
+	mng.(Instrumented).SetMetricsRecorder(metrics.NewPrometheusRecorder())
+	mng.(Instrumented).SetMetricsRecorder(nil)
+	wg.Wait()
+}
+
+func TestRegisterActorImplFactoryWhileTurnsRun(t *testing.T) {
+	mng, err := NewDefaultActorManagerWithConfig(config.GetConfigFromOptions(
+		config.WithStateProviderName(stateConstant.MemoryStateProviderName),
+	), nil)
+	assert.NoError(t, err)
+	factory := func() actor.Server {
+		return &CounterActor{}
+	}
+	mng.RegisterActorImplFactory(factory)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := mng.InvokeMethod(fmt.Sprintf("actor-%d", i), "Add", []byte(`1`))
+			assert.NoError(t, err)
+		}(i)
+	}
+	mng.RegisterActorImplFactory(factory)
+	wg.Wait()
+	assert.Equal(t, "counterActorType", mng.(*DefaultActorManager).registeredType())
 }
@@ -0,0 +1,117 @@
+package manager
+
+import (
+	"context"
+
+	perrors "github.com/pkg/errors"
+
+	"github.com/dapr/go-sdk/actor"
+	"github.com/dapr/go-sdk/actor/codec"
+	dapr "github.com/dapr/go-sdk/client"
+)
+
+// daprScheduler is the actor.Scheduler of one actor instance, which registers its reminders and timers to Dapr.
+type daprScheduler struct {
+	actorType  string
+	actorID    string
+	serializer codec.Codec
+	// getDaprClient is called on registration, so that actors not using reminders and timers don't need the client
+	getDaprClient func() (dapr.Client, error)
+	// hasMethod validates timer callbacks on registration, they are not validated if it's nil
+	hasMethod func(methodName string) bool
+}
+
+func newDaprScheduler(actorType, actorID string, serializer codec.Codec, getDaprClient func() (dapr.Client, error),
+	hasMethod func(methodName string) bool) *daprScheduler {
+	return &daprScheduler{
+		actorType:     actorType,
+		actorID:       actorID,
+		serializer:    serializer,
+		getDaprClient: getDaprClient,
+		hasMethod:     hasMethod,
+	}
+}
+
+func (s *daprScheduler) RegisterReminder(ctx context.Context, name string, schedule *actor.Schedule, data interface{}) error {
+	daprClient, err := s.client()
+	if err != nil {
+		return err
+	}
+	req := &dapr.RegisterActorReminderRequest{
+		ActorType: s.actorType,
+		ActorID:   s.actorID,
+		Name:      name,
+	}
+	if req.DueTime, req.Period, req.TTL, err = schedule.Format(); err != nil {
+		return err
+	}
+	if req.Data, err = s.encode(data); err != nil {
+		return err
+	}
+	return daprClient.RegisterActorReminder(ctx, req)
+}
+
+func (s *daprScheduler) UnregisterReminder(ctx context.Context, name string) error {
+	daprClient, err := s.client()
+	if err != nil {
+		return err
+	}
+	return daprClient.UnregisterActorReminder(ctx, &dapr.UnregisterActorReminderRequest{
+		ActorType: s.actorType,
+		ActorID:   s.actorID,
+		Name:      name,
+	})
+}
+
+// RegisterTimer registers timer @name, which invokes method @callback of the actor with @data. It fails if the actor
+// has no method @callback, rather than failing every time the timer fires.
+func (s *daprScheduler) RegisterTimer(ctx context.Context, name string, schedule *actor.Schedule, callback string, data interface{}) error {
+	if s.hasMethod != nil && !s.hasMethod(callback) {
+		return perrors.Errorf("timer callback %s is not a method of actor type %s", callback, s.actorType)
+	}
+	daprClient, err := s.client()
+	if err != nil {
+		return err
+	}
+	req := &dapr.RegisterActorTimerRequest{
+		ActorType: s.actorType,
+		ActorID:   s.actorID,
+		Name:      name,
+		CallBack:  callback,
+	}
+	if req.DueTime, req.Period, req.TTL, err = schedule.Format(); err != nil {
+		return err
+	}
+	if req.Data, err = s.encode(data); err != nil {
+		return err
+	}
+	return daprClient.RegisterActorTimer(ctx, req)
+}
+
+func (s *daprScheduler) UnregisterTimer(ctx context.Context, name string) error {
+	daprClient, err := s.client()
+	if err != nil {
+		return err
+	}
+	return daprClient.UnregisterActorTimer(ctx, &dapr.UnregisterActorTimerRequest{
+		ActorType: s.actorType,
+		ActorID:   s.actorID,
+		Name:      name,
+	})
+}
+
+func (s *daprScheduler) client() (dapr.Client, error) {
+	return s.getDaprClient()
+}
+
+// encode encodes @data with the serializer of the actor, nil data is encoded as empty.
+func (s *daprScheduler) encode(data interface{}) ([]byte, error) {
+	if data == nil {
+		return nil, nil
+	}
+	encoded, err := s.serializer.Marshal(data)
+	if err != nil {
+		return nil, perrors.Wrap(err, "failed to encode schedule data")
+	}
+	return encoded, nil
+}
@@ -0,0 +1,209 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	perrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dapr/go-sdk/actor"
+	"github.com/dapr/go-sdk/actor/codec"
+	actorErr "github.com/dapr/go-sdk/actor/error"
+	"github.com/dapr/go-sdk/actor/mock"
+	dapr "github.com/dapr/go-sdk/client"
+)
+
+// scheduleClient records the reminders and timers registered to it.
+type scheduleClient struct {
+	dapr.Client
+	reminders map[string]*dapr.RegisterActorReminderRequest
+	timers    map[string]*dapr.RegisterActorTimerRequest
+}
+
+func newScheduleClient() *scheduleClient {
+	return &scheduleClient{
+		reminders: make(map[string]*dapr.RegisterActorReminderRequest),
+		timers:    make(map[string]*dapr.RegisterActorTimerRequest),
+	}
+}
+
+func (c *scheduleClient) RegisterActorReminder(ctx context.Context, req *dapr.RegisterActorReminderRequest) error {
+	c.reminders[req.Name] = req
+	return nil
+}
+
+func (c *scheduleClient) UnregisterActorReminder(ctx context.Context, req *dapr.UnregisterActorReminderRequest) error {
+	delete(c.reminders, req.Name)
+	return nil
+}
+
+func (c *scheduleClient) RegisterActorTimer(ctx context.Context, req *dapr.RegisterActorTimerRequest) error {
+	c.timers[req.Name] = req
+	return nil
+}
+
+func (c *scheduleClient) UnregisterActorTimer(ctx context.Context, req *dapr.UnregisterActorTimerRequest) error {
+	delete(c.timers, req.Name)
+	return nil
+}
+
+type ScheduleActor struct {
+	actor.ServerImplBase
+	reminders []*actor.Reminder
+}
+
+func (a *ScheduleActor) Type() string {
+	return "scheduleActorType"
+}
+
+func (a *ScheduleActor) Start(ctx context.Context, count int) error {
+	if err := a.RegisterReminder(ctx, "reminder", time.Second, time.Minute, count); err != nil {
+		return err
+	}
+	if err := a.ScheduleReminder(ctx, "limited", actor.NewSchedule(0, 10*time.Second).WithRepetitions(5).WithTTL(time.Minute), nil); err != nil {
+		return err
+	}
+	return a.RegisterTimer(ctx, "timer", 0, 0, "Tick", count)
+}
+
+// Tick is the timer callback, which is done once the count is zero.
+func (a *ScheduleActor) Tick(ctx context.Context, count int) error {
+	if count == 0 {
+		return actor.ErrScheduleDone
+	}
+	return nil
+}
+
+func (a *ScheduleActor) Stop(ctx context.Context) error {
+	if err := a.UnregisterReminder(ctx, "reminder"); err != nil {
+		return err
+	}
+	return a.UnregisterTimer(ctx, "timer")
+}
+
+func (a *ScheduleActor) HandleReminder(ctx context.Context, reminder *actor.Reminder) error {
+	a.reminders = append(a.reminders, reminder)
+	count := 0
+	if err := reminder.Decode(&count); err == nil && count == 0 {
+		return perrors.Wrap(actor.ErrScheduleDone, "count down")
+	}
+	return nil
+}
+
+func TestScheduler(t *testing.T) {
+	daprClient := newScheduleClient()
+	mng, aerr := NewDefaultActorManagerWithClient("json", daprClient)
+	assert.NoError(t, aerr)
+	mng.RegisterActorImplFactory(func() actor.Server {
+		return &ScheduleActor{}
+	})
+
+	_, aerr = mng.InvokeMethod("testActorID", "Start", []byte(`3`))
+	assert.NoError(t, aerr)
+	assert.Equal(t, &dapr.RegisterActorReminderRequest{
+		ActorType: "scheduleActorType",
+		ActorID:   "testActorID",
+		Name:      "reminder",
+		DueTime:   "1s",
+		Period:    "1m0s",
+		Data:      []byte(`3`),
+	}, daprClient.reminders["reminder"])
+	assert.Equal(t, &dapr.RegisterActorTimerRequest{
+		ActorType: "scheduleActorType",
+		ActorID:   "testActorID",
+		Name:      "timer",
+		DueTime:   "0s",
+		CallBack:  "Tick",
+		Data:      []byte(`3`),
+	}, daprClient.timers["timer"])
+
+	_, aerr = mng.InvokeMethod("testActorID", "Stop", nil)
+	assert.NoError(t, aerr)
+	assert.NotContains(t, daprClient.reminders, "reminder")
+	assert.Empty(t, daprClient.timers)
+}
+
+func TestHandleReminder(t *testing.T) {
+	mng, aerr := NewDefaultActorManagerWithClient("json", mock.NewDaprClient())
+	assert.NoError(t, aerr)
+	scheduleActor := &ScheduleActor{}
+	mng.RegisterActorImplFactory(func() actor.Server {
+		return scheduleActor
+	})
+
+	aerr = mng.InvokeReminder("testActorID", "reminder", []byte(`{"data":"Mw==","dueTime":"1s","period":"1m0s"}`))
+	assert.NoError(t, aerr)
+	assert.Len(t, scheduleActor.reminders, 1)
+	reminder := scheduleActor.reminders[0]
+	assert.Equal(t, "reminder", reminder.Name)
+	assert.Equal(t, time.Second, reminder.DueTime)
+	assert.Equal(t, time.Minute, reminder.Period)
+	count := 0
+	assert.NoError(t, reminder.Decode(&count))
+	assert.Equal(t, 3, count)
+
+	aerr = mng.InvokeReminder("testActorID", "reminder", []byte(`{"dueTime":"1 second"}`))
+	assert.ErrorIs(t, aerr, actorErr.ErrRemindersParamsInvalid)
+}
+
+func TestScheduleDone(t *testing.T) {
+	daprClient := newScheduleClient()
+	mng, aerr := NewDefaultActorManagerWithClient("json", daprClient)
+	assert.NoError(t, aerr)
+	mng.RegisterActorImplFactory(func() actor.Server {
+		return &ScheduleActor{}
+	})
+
+	_, aerr = mng.InvokeMethod("testActorID", "Start", []byte(`3`))
+	assert.NoError(t, aerr)
+	assert.Equal(t, &dapr.RegisterActorReminderRequest{
+		ActorType: "scheduleActorType",
+		ActorID:   "testActorID",
+		Name:      "limited",
+		DueTime:   "0s",
+		Period:    "R5/PT10S",
+		TTL:       "1m0s",
+	}, daprClient.reminders["limited"])
+
+	// the reminder and the timer are unregistered once their callbacks report they are done
+	aerr = mng.InvokeReminder("testActorID", "reminder", []byte(`{"data":"MA==","dueTime":"1s","period":"1m0s"}`))
+	assert.NoError(t, aerr)
+	assert.NotContains(t, daprClient.reminders, "reminder")
+	assert.Contains(t, daprClient.reminders, "limited")
+	aerr = mng.InvokeTimer("testActorID", "timer", []byte(`{"callback":"Tick","data":"MA==","dueTime":"0s"}`))
+	assert.NoError(t, aerr)
+	assert.Empty(t, daprClient.timers)
+
+	// callbacks invoked as actor methods can't be done
+	_, aerr = mng.InvokeMethod("testActorID", "Tick", []byte(`0`))
+	assert.ErrorIs(t, aerr, actorErr.ErrActorInvokeFailed)
+}
+
+func TestSchedulerWithoutDaprClient(t *testing.T) {
+	serializer, _ := codec.GetActorCodec("json")
+	scheduler := newDaprScheduler("scheduleActorType", "testActorID", serializer, func() (dapr.Client, error) {
+		return nil, actorErr.ErrDaprClientNotAvailable
+	}, nil)
+	assert.ErrorIs(t, scheduler.RegisterReminder(context.Background(), "reminder", actor.NewSchedule(time.Second, 0), nil), actorErr.ErrDaprClientNotAvailable)
+
+	// actors not created by the actor manager have no scheduler
+	assert.Error(t, (&ScheduleActor{}).RegisterTimer(context.Background(), "timer", 0, 0, "Tick", nil))
+}
+
+func TestRegisterTimerCallback(t *testing.T) {
+	daprClient := newScheduleClient()
+	serializer, _ := codec.GetActorCodec("json")
+	scheduler := newDaprScheduler("scheduleActorType", "testActorID", serializer, func() (dapr.Client, error) {
+		return daprClient, nil
+	}, func(methodName string) bool {
+		return methodName == "Tick"
+	})
+
+	assert.NoError(t, scheduler.RegisterTimer(context.Background(), "timer", actor.NewSchedule(0, time.Second), "Tick", nil))
+	assert.Contains(t, daprClient.timers, "timer")
+	// timers with unknown callbacks are rejected on registration, rather than failing every time they fire
+	assert.Error(t, scheduler.RegisterTimer(context.Background(), "typo", actor.NewSchedule(0, time.Second), "Tock", nil))
+	assert.NotContains(t, daprClient.timers, "typo")
+}
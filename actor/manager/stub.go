@@ -0,0 +1,86 @@
+package manager
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	perrors "github.com/pkg/errors"
+
+	"github.com/dapr/go-sdk/actor"
+)
+
+// ValidateClientStub checks that every method declared by the client stub @stub is implemented by the actor
+// server @impl with the same arguments and reply, so that a mismatch is reported when the actor is registered
+// instead of failing at invocation time. The leading context.Context argument is ignored on both sides.
+// The actor runtime validates the stubs set by config.WithClientStubs on registration.
+func ValidateClientStub(stub actor.Client, impl actor.Server) error {
+	return ValidateClientStubOfType(stub, impl.Type(), impl)
+}
+
+// ValidateClientStubOfType validates the client stub @stub as ValidateClientStub does, against the actor server
+// @impl registered as @actorType, which may not be the type @impl returns.
+func ValidateClientStubOfType(stub actor.Client, actorType string, impl actor.Server) error {
+	stubValue := reflect.ValueOf(stub)
+	if stubValue.Kind() != reflect.Ptr || stubValue.Elem().Kind() != reflect.Struct {
+		return perrors.Errorf("client stub %T is not a pointer to struct", stub)
+	}
+	if stub.Type() != actorType {
+		return perrors.Errorf("client stub type %s mismatches actor type %s", stub.Type(), actorType)
+	}
+	methods, err := getAbsctractMethodMap(impl)
+	if err != nil {
+		return err
+	}
+
+	stubType := stubValue.Elem().Type()
+	mismatches := make([]string, 0)
+	for i := 0; i < stubType.NumField(); i++ {
+		field := stubType.Field(i)
+		if field.Type.Kind() != reflect.Func {
+			continue
+		}
+		mt, ok := methods[field.Name]
+		if !ok {
+			mismatches = append(mismatches, fmt.Sprintf("method %s is not implemented by actor %s", field.Name, actorType))
+			continue
+		}
+		if err := compareSignature(field.Type, mt); err != nil {
+			mismatches = append(mismatches, fmt.Sprintf("method %s: %s", field.Name, err))
+		}
+	}
+	if len(mismatches) > 0 {
+		return perrors.Errorf("client stub %T mismatches actor %s: %s", stub, actorType, strings.Join(mismatches, "; "))
+	}
+	return nil
+}
+
+func compareSignature(fn reflect.Type, mt *MethodType) error {
+	argsType := make([]reflect.Type, 0, fn.NumIn())
+	for i := 0; i < fn.NumIn(); i++ {
+		if i == 0 && fn.In(i).String() == "context.Context" {
+			continue
+		}
+		argsType = append(argsType, fn.In(i))
+	}
+	if len(argsType) != len(mt.argsType) {
+		return perrors.Errorf("stub takes %d arguments, actor takes %d", len(argsType), len(mt.argsType))
+	}
+	for i, typ := range argsType {
+		if typ != mt.argsType[i] {
+			return perrors.Errorf("argument %d is %s in stub, %s in actor", i, typ, mt.argsType[i])
+		}
+	}
+
+	switch {
+	case fn.NumOut() == 0 || fn.NumOut() > 2 || fn.Out(fn.NumOut()-1) != typeOfError:
+		return perrors.Errorf("stub must return error or (reply, error), got %s", fn)
+	case fn.NumOut() == 1 && mt.replyType != nil:
+		return perrors.Errorf("stub returns no reply, actor returns %s", mt.replyType)
+	case fn.NumOut() == 2 && mt.replyType == nil:
+		return perrors.Errorf("stub returns %s, actor returns no reply", fn.Out(0))
+	case fn.NumOut() == 2 && fn.Out(0) != mt.replyType:
+		return perrors.Errorf("reply is %s in stub, %s in actor", fn.Out(0), mt.replyType)
+	}
+	return nil
+}
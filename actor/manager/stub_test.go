@@ -0,0 +1,42 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dapr/go-sdk/actor/mock"
+)
+
+type validStub struct {
+	Invoke func(context.Context, string) (string, error)
+	Add    func(context.Context, int64, int64, string) (string, error)
+	Ping   func() error
+}
+
+func (s *validStub) Type() string { return "testActorType" }
+func (s *validStub) ID() string   { return "testActorID" }
+
+type invalidStub struct {
+	Invoke  func(context.Context, int) (string, error)
+	Add     func(context.Context, int64, int64) (string, error)
+	Ping    func() (string, error)
+	Missing func(context.Context) error
+}
+
+func (s *invalidStub) Type() string { return "testActorType" }
+func (s *invalidStub) ID() string   { return "testActorID" }
+
+func TestValidateClientStub(t *testing.T) {
+	assert.NoError(t, ValidateClientStub(&validStub{}, mock.ActorImplFactory()))
+
+	err := ValidateClientStub(&invalidStub{}, mock.ActorImplFactory())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "method Invoke: argument 0 is int in stub, string in actor")
+	assert.Contains(t, err.Error(), "method Add: stub takes 2 arguments, actor takes 3")
+	assert.Contains(t, err.Error(), "method Ping: stub returns string, actor returns no reply")
+	assert.Contains(t, err.Error(), "method Missing is not implemented by actor testActorType")
+
+	assert.Error(t, ValidateClientStub(&validStub{}, mock.NotReminderCalleeActorFactory()))
+}
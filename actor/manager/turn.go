@@ -0,0 +1,52 @@
+package manager
+
+import "sync"
+
+// turnGate runs the turns of an actor one at a time, as Dapr does, so that the turns waiting for the actor are known
+// to the SDK. The calls of the reentrant call chain holding the actor, identified by the reentrancy ID Dapr sends,
+// enter the turn of the chain rather than waiting for it to end, the calls without a reentrancy ID wait.
+type turnGate struct {
+	lock sync.Mutex
+	cond *sync.Cond
+	// chain is the reentrancy ID of the call chain holding the actor
+	chain string
+	// depth is the number of turns of the chain in the actor, the actor is free if it's 0
+	depth int
+}
+
+func newTurnGate() *turnGate {
+	g := &turnGate{}
+	g.cond = sync.NewCond(&g.lock)
+	return g
+}
+
+// enter enters a turn of the call chain @reentrancyID, which waits until the actor is free or held by the chain.
+// @queued is called if the turn has to wait, and @dequeued once it stops waiting.
+func (g *turnGate) enter(reentrancyID string, queued, dequeued func()) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if !g.canEnter(reentrancyID) {
+		queued()
+		for !g.canEnter(reentrancyID) {
+			g.cond.Wait()
+		}
+		dequeued()
+	}
+	g.chain = reentrancyID
+	g.depth++
+}
+
+func (g *turnGate) canEnter(reentrancyID string) bool {
+	return g.depth == 0 || (reentrancyID != "" && reentrancyID == g.chain)
+}
+
+// leave leaves the turn entered by enter, the actor is free once the chain left all its turns.
+func (g *turnGate) leave() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.depth--
+	if g.depth == 0 {
+		g.chain = ""
+		g.cond.Broadcast()
+	}
+}
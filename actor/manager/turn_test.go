@@ -0,0 +1,49 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTurnGate(t *testing.T) {
+	g := newTurnGate()
+	queued := make(chan string, 2)
+	dequeued := make(chan string, 2)
+	enter := func(reentrancyID string) {
+		g.enter(reentrancyID, func() {
+			queued <- reentrancyID
+		}, func() {
+			dequeued <- reentrancyID
+		})
+	}
+
+	enter("chain-1")
+	// the calls of the chain holding the actor enter it
+	enter("chain-1")
+	assert.Empty(t, queued)
+
+	entered := make(chan string, 2)
+	for _, id := range []string{"chain-2", ""} {
+		go func(id string) {
+			enter(id)
+			entered <- id
+			g.leave()
+		}(id)
+	}
+	assert.ElementsMatch(t, []string{"chain-2", ""}, []string{<-queued, <-queued})
+
+	g.leave()
+	select {
+	case id := <-entered:
+		t.Fatalf("turn of chain %q entered while the actor is held", id)
+	case <-time.After(10 * time.Millisecond):
+	}
+	g.leave()
+	assert.ElementsMatch(t, []string{"chain-2", ""}, []string{<-entered, <-entered})
+	assert.ElementsMatch(t, []string{"chain-2", ""}, []string{<-dequeued, <-dequeued})
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	assert.Equal(t, 0, g.depth)
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import "time"
+
+// TurnKind is the kind of an actor turn, a turn is the invocation of an actor method, timer or reminder.
+type TurnKind string
+
+const (
+	MethodTurn   = TurnKind("method")
+	TimerTurn    = TurnKind("timer")
+	ReminderTurn = TurnKind("reminder")
+)
+
+// Recorder is the metrics hook of the actor runtime, it's called by the actor managers of all actor types, so it
+// must be safe for concurrent use.
+type Recorder interface {
+	// ActorActivated records that an actor of @actorType is activated.
+	ActorActivated(actorType string)
+	// ActorDeactivated records that an actor of @actorType is deactivated.
+	ActorDeactivated(actorType string)
+	// TurnStarted records that a turn of @kind of an actor of @actorType is received. The turns started and not ended
+	// yet are the turns in this process across the actors of the type, running or queued for their actor.
+	TurnStarted(actorType string, kind TurnKind)
+	// TurnQueued records that the turn of @kind started by TurnStarted waits for the turn of another call chain of its
+	// actor to end, as the turns of an actor are run one at a time.
+	TurnQueued(actorType string, kind TurnKind)
+	// TurnDequeued records that the turn of @kind queued by TurnQueued stops waiting. The turns queued and not
+	// dequeued yet are the queue depth of the actors of the type.
+	TurnDequeued(actorType string, kind TurnKind)
+	// TurnEnded records that the turn of @kind started by TurnStarted ended after @elapsed, with the result @err.
+	TurnEnded(actorType string, kind TurnKind, elapsed time.Duration, err error)
+	// StateSaved records that the states of an actor of @actorType are saved in @elapsed, with the result @err.
+	StateSaved(actorType string, elapsed time.Duration, err error)
+	// SerializationFailed records that the arguments or the reply of an actor method of @actorType can't be
+	// serialized.
+	SerializationFailed(actorType string)
+}
+
+// NopRecorder records nothing, it's the recorder of the actor runtime by default.
+type NopRecorder struct{}
+
+func (NopRecorder) ActorActivated(string) {}
+
+func (NopRecorder) ActorDeactivated(string) {}
+
+func (NopRecorder) TurnStarted(string, TurnKind) {}
+
+func (NopRecorder) TurnQueued(string, TurnKind) {}
+
+func (NopRecorder) TurnDequeued(string, TurnKind) {}
+
+func (NopRecorder) TurnEnded(string, TurnKind, time.Duration, error) {}
+
+func (NopRecorder) StateSaved(string, time.Duration, error) {}
+
+func (NopRecorder) SerializationFailed(string) {}
@@ -0,0 +1,174 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/common/expfmt"
+)
+
+// DefaultBuckets are the upper bounds in seconds of the latency histograms, the Prometheus client defaults.
+var DefaultBuckets = prometheus.DefBuckets
+
+const (
+	statusSuccess = "success"
+	statusFailure = "failure"
+)
+
+// PrometheusRecorder is a Recorder keeping the metrics in Prometheus collectors. It's a prometheus.Collector itself,
+// so that it can be registered to the registry of the application, and it serves its own registry by ServeHTTP, so
+// that it can be mounted as the /metrics endpoint scraped by Prometheus.
+type PrometheusRecorder struct {
+	registry *prometheus.Registry
+
+	activeActors          *prometheus.GaugeVec
+	activations           *prometheus.CounterVec
+	deactivations         *prometheus.CounterVec
+	inFlightTurns         *prometheus.GaugeVec
+	turnQueueDepth        *prometheus.GaugeVec
+	serializationFailures *prometheus.CounterVec
+	turnDurations         *prometheus.HistogramVec
+	stateSaveDurations    *prometheus.HistogramVec
+}
+
+// NewPrometheusRecorder creates a PrometheusRecorder, whose latency histograms have DefaultBuckets.
+func NewPrometheusRecorder() *PrometheusRecorder {
+	return NewPrometheusRecorderWithBuckets(DefaultBuckets)
+}
+
+// NewPrometheusRecorderWithBuckets creates a PrometheusRecorder, whose latency histograms have the upper bounds in
+// seconds @buckets.
+func NewPrometheusRecorderWithBuckets(buckets []float64) *PrometheusRecorder {
+	p := &PrometheusRecorder{
+		registry: prometheus.NewRegistry(),
+		activeActors: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "dapr_actor_active_actors",
+			Help: "Number of active actors.",
+		}, []string{"actor_type"}),
+		activations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "dapr_actor_activations_total",
+			Help: "Number of actor activations.",
+		}, []string{"actor_type"}),
+		deactivations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "dapr_actor_deactivations_total",
+			Help: "Number of actor deactivations.",
+		}, []string{"actor_type"}),
+		inFlightTurns: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "dapr_actor_in_flight_turns",
+			Help: "Number of actor turns received and not ended yet, including the queued ones.",
+		}, []string{"actor_type"}),
+		turnQueueDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "dapr_actor_turn_queue_depth",
+			Help: "Number of actor turns waiting for the turn of another call chain of their actor to end.",
+		}, []string{"actor_type"}),
+		serializationFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "dapr_actor_serialization_failures_total",
+			Help: "Number of actor arguments or replies failed to be serialized.",
+		}, []string{"actor_type"}),
+		turnDurations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "dapr_actor_turn_duration_seconds",
+			Help:    "Latency of actor method, timer and reminder turns.",
+			Buckets: buckets,
+		}, []string{"actor_type", "kind", "status"}),
+		stateSaveDurations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "dapr_actor_state_save_duration_seconds",
+			Help:    "Latency of actor state saves.",
+			Buckets: buckets,
+		}, []string{"actor_type", "status"}),
+	}
+	p.registry.MustRegister(p)
+	return p
+}
+
+// collectors returns the collectors of the metrics of the recorder.
+func (p *PrometheusRecorder) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		p.activeActors,
+		p.activations,
+		p.deactivations,
+		p.inFlightTurns,
+		p.turnQueueDepth,
+		p.serializationFailures,
+		p.turnDurations,
+		p.stateSaveDurations,
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (p *PrometheusRecorder) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range p.collectors() {
+		c.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (p *PrometheusRecorder) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range p.collectors() {
+		c.Collect(ch)
+	}
+}
+
+func (p *PrometheusRecorder) ActorActivated(actorType string) {
+	p.activeActors.WithLabelValues(actorType).Inc()
+	p.activations.WithLabelValues(actorType).Inc()
+}
+
+func (p *PrometheusRecorder) ActorDeactivated(actorType string) {
+	p.activeActors.WithLabelValues(actorType).Dec()
+	p.deactivations.WithLabelValues(actorType).Inc()
+}
+
+func (p *PrometheusRecorder) TurnStarted(actorType string, kind TurnKind) {
+	p.inFlightTurns.WithLabelValues(actorType).Inc()
+}
+
+func (p *PrometheusRecorder) TurnQueued(actorType string, kind TurnKind) {
+	p.turnQueueDepth.WithLabelValues(actorType).Inc()
+}
+
+func (p *PrometheusRecorder) TurnDequeued(actorType string, kind TurnKind) {
+	p.turnQueueDepth.WithLabelValues(actorType).Dec()
+}
+
+func (p *PrometheusRecorder) TurnEnded(actorType string, kind TurnKind, elapsed time.Duration, err error) {
+	p.inFlightTurns.WithLabelValues(actorType).Dec()
+	p.turnDurations.WithLabelValues(actorType, string(kind), status(err)).Observe(elapsed.Seconds())
+}
+
+func (p *PrometheusRecorder) StateSaved(actorType string, elapsed time.Duration, err error) {
+	p.stateSaveDurations.WithLabelValues(actorType, status(err)).Observe(elapsed.Seconds())
+}
+
+func (p *PrometheusRecorder) SerializationFailed(actorType string) {
+	p.serializationFailures.WithLabelValues(actorType).Inc()
+}
+
+// ServeHTTP answers the metrics in the format negotiated with the scraper, as promhttp does.
+func (p *PrometheusRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+}
+
+// Write writes the metrics to @w in the Prometheus text format, the metrics are sorted by name and their series by
+// labels.
+func (p *PrometheusRecorder) Write(w io.Writer) error {
+	families, err := p.registry.Gather()
+	if err != nil {
+		return err
+	}
+	for _, family := range families {
+		if _, err := expfmt.MetricFamilyToText(w, family); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func status(err error) string {
+	if err != nil {
+		return statusFailure
+	}
+	return statusSuccess
+}
@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Recorder = NopRecorder{}
+
+func TestPrometheusRecorder(t *testing.T) {
+	p := NewPrometheusRecorderWithBuckets([]float64{.1, 1})
+
+	p.ActorActivated("typeA")
+	p.ActorActivated("typeA")
+	p.ActorActivated(`type"B`)
+	p.ActorDeactivated("typeA")
+	p.TurnStarted("typeA", MethodTurn)
+	p.TurnStarted("typeA", MethodTurn)
+	p.TurnQueued("typeA", MethodTurn)
+	p.TurnStarted("typeA", ReminderTurn)
+	p.TurnQueued("typeA", ReminderTurn)
+	p.TurnDequeued("typeA", MethodTurn)
+	p.TurnEnded("typeA", MethodTurn, 50*time.Millisecond, nil)
+	p.TurnStarted("typeA", TimerTurn)
+	p.TurnEnded("typeA", TimerTurn, 2*time.Second, errors.New("failed"))
+	p.StateSaved("typeA", 500*time.Millisecond, nil)
+	p.SerializationFailed("typeA")
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, p.Write(buf))
+	assert.Equal(t, `# HELP dapr_actor_activations_total Number of actor activations.
+# TYPE dapr_actor_activations_total counter
+dapr_actor_activations_total{actor_type="type\"B"} 1
+dapr_actor_activations_total{actor_type="typeA"} 2
+# HELP dapr_actor_active_actors Number of active actors.
+# TYPE dapr_actor_active_actors gauge
+dapr_actor_active_actors{actor_type="type\"B"} 1
+dapr_actor_active_actors{actor_type="typeA"} 1
+# HELP dapr_actor_deactivations_total Number of actor deactivations.
+# TYPE dapr_actor_deactivations_total counter
+dapr_actor_deactivations_total{actor_type="typeA"} 1
+# HELP dapr_actor_in_flight_turns Number of actor turns received and not ended yet, including the queued ones.
+# TYPE dapr_actor_in_flight_turns gauge
+dapr_actor_in_flight_turns{actor_type="typeA"} 2
+# HELP dapr_actor_serialization_failures_total Number of actor arguments or replies failed to be serialized.
+# TYPE dapr_actor_serialization_failures_total counter
+dapr_actor_serialization_failures_total{actor_type="typeA"} 1
+# HELP dapr_actor_state_save_duration_seconds Latency of actor state saves.
+# TYPE dapr_actor_state_save_duration_seconds histogram
+dapr_actor_state_save_duration_seconds_bucket{actor_type="typeA",status="success",le="0.1"} 0
+dapr_actor_state_save_duration_seconds_bucket{actor_type="typeA",status="success",le="1"} 1
+dapr_actor_state_save_duration_seconds_bucket{actor_type="typeA",status="success",le="+Inf"} 1
+dapr_actor_state_save_duration_seconds_sum{actor_type="typeA",status="success"} 0.5
+dapr_actor_state_save_duration_seconds_count{actor_type="typeA",status="success"} 1
+# HELP dapr_actor_turn_duration_seconds Latency of actor method, timer and reminder turns.
+# TYPE dapr_actor_turn_duration_seconds histogram
+dapr_actor_turn_duration_seconds_bucket{actor_type="typeA",kind="method",status="success",le="0.1"} 1
+dapr_actor_turn_duration_seconds_bucket{actor_type="typeA",kind="method",status="success",le="1"} 1
+dapr_actor_turn_duration_seconds_bucket{actor_type="typeA",kind="method",status="success",le="+Inf"} 1
+dapr_actor_turn_duration_seconds_sum{actor_type="typeA",kind="method",status="success"} 0.05
+dapr_actor_turn_duration_seconds_count{actor_type="typeA",kind="method",status="success"} 1
+dapr_actor_turn_duration_seconds_bucket{actor_type="typeA",kind="timer",status="failure",le="0.1"} 0
+dapr_actor_turn_duration_seconds_bucket{actor_type="typeA",kind="timer",status="failure",le="1"} 0
+dapr_actor_turn_duration_seconds_bucket{actor_type="typeA",kind="timer",status="failure",le="+Inf"} 1
+dapr_actor_turn_duration_seconds_sum{actor_type="typeA",kind="timer",status="failure"} 2
+dapr_actor_turn_duration_seconds_count{actor_type="typeA",kind="timer",status="failure"} 1
+# HELP dapr_actor_turn_queue_depth Number of actor turns waiting for the turn of another call chain of their actor to end.
+# TYPE dapr_actor_turn_queue_depth gauge
+dapr_actor_turn_queue_depth{actor_type="typeA"} 1
+`, buf.String())
+
+	rr := httptest.NewRecorder()
+	p.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Equal(t, buf.String(), rr.Body.String())
+
+	// the recorder can be registered to the registry of the application
+	registry := prometheus.NewRegistry()
+	assert.NoError(t, registry.Register(p))
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+	assert.Len(t, families, 8)
+}
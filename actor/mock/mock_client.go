@@ -0,0 +1,16 @@
+package mock
+
+import (
+	"github.com/dapr/go-sdk/client"
+)
+
+// DaprClient is a fake client.Client to be injected into actor runtimes and managers by tests. Methods not overridden
+// by embedding it panic when called.
+type DaprClient struct {
+	client.Client
+}
+
+// NewDaprClient creates a fake client.Client.
+func NewDaprClient() client.Client {
+	return &DaprClient{}
+}
@@ -33,6 +33,20 @@ func (m *MockCodec) EXPECT() *MockCodecMockRecorder {
 	return m.recorder
 }
 
+// ContentType mocks base method.
+func (m *MockCodec) ContentType() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContentType")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ContentType indicates an expected call of ContentType.
+func (mr *MockCodecMockRecorder) ContentType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContentType", reflect.TypeOf((*MockCodec)(nil).ContentType))
+}
+
 // Marshal mocks base method.
 func (m *MockCodec) Marshal(arg0 interface{}) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -10,7 +10,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 
 	actor "github.com/dapr/go-sdk/actor"
-	error "github.com/dapr/go-sdk/actor/error"
+	codec "github.com/dapr/go-sdk/actor/codec"
 )
 
 // MockActorContainer is a mock of ActorContainer interface.
@@ -50,12 +50,26 @@ func (mr *MockActorContainerMockRecorder) GetActor() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActor", reflect.TypeOf((*MockActorContainer)(nil).GetActor))
 }
 
+// HasMethod mocks base method.
+func (m *MockActorContainer) HasMethod(arg0 string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasMethod", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasMethod indicates an expected call of HasMethod.
+func (mr *MockActorContainerMockRecorder) HasMethod(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasMethod", reflect.TypeOf((*MockActorContainer)(nil).HasMethod), arg0)
+}
+
 // Invoke mocks base method.
-func (m *MockActorContainer) Invoke(arg0 string, arg1 []byte) ([]reflect.Value, error.ActorErr) {
+func (m *MockActorContainer) Invoke(arg0 string, arg1 []byte) ([]byte, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Invoke", arg0, arg1)
-	ret0, _ := ret[0].([]reflect.Value)
-	ret1, _ := ret[1].(error.ActorErr)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
@@ -64,3 +78,18 @@ func (mr *MockActorContainerMockRecorder) Invoke(arg0, arg1 interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invoke", reflect.TypeOf((*MockActorContainer)(nil).Invoke), arg0, arg1)
 }
+
+// InvokeWithCodec mocks base method.
+func (m *MockActorContainer) InvokeWithCodec(arg0 string, arg1 codec.Codec, arg2 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvokeWithCodec", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvokeWithCodec indicates an expected call of InvokeWithCodec.
+func (mr *MockActorContainerMockRecorder) InvokeWithCodec(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeWithCodec", reflect.TypeOf((*MockActorContainer)(nil).InvokeWithCodec), arg0, arg1, arg2)
+}
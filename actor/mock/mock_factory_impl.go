@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/dapr/go-sdk/actor"
 )
@@ -22,6 +23,14 @@ func (t *ActorImpl) Invoke(ctx context.Context, req string) (string, error) {
 	return req, nil
 }
 
+func (t *ActorImpl) Add(ctx context.Context, a, b int64, tag string) (string, error) {
+	return fmt.Sprintf("%s:%d", tag, a+b), nil
+}
+
+func (t *ActorImpl) Ping() error {
+	return nil
+}
+
 func (t *ActorImpl) ReminderCall(reminderName string, state []byte, dueTime string, period string) {
 }
 
@@ -10,7 +10,6 @@ import (
 	gomock "github.com/golang/mock/gomock"
 
 	actor "github.com/dapr/go-sdk/actor"
-	error "github.com/dapr/go-sdk/actor/error"
 )
 
 // MockActorManager is a mock of ActorManager interface.
@@ -37,10 +36,10 @@ func (m *MockActorManager) EXPECT() *MockActorManagerMockRecorder {
 }
 
 // DetectiveActor mocks base method.
-func (m *MockActorManager) DetectiveActor(arg0 string) error.ActorErr {
+func (m *MockActorManager) DetectiveActor(arg0 string) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DetectiveActor", arg0)
-	ret0, _ := ret[0].(error.ActorErr)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
@@ -51,11 +50,11 @@ func (mr *MockActorManagerMockRecorder) DetectiveActor(arg0 interface{}) *gomock
 }
 
 // InvokeMethod mocks base method.
-func (m *MockActorManager) InvokeMethod(arg0, arg1 string, arg2 []byte) ([]byte, error.ActorErr) {
+func (m *MockActorManager) InvokeMethod(arg0, arg1 string, arg2 []byte) ([]byte, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "InvokeMethod", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]byte)
-	ret1, _ := ret[1].(error.ActorErr)
+	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
@@ -65,11 +64,26 @@ func (mr *MockActorManagerMockRecorder) InvokeMethod(arg0, arg1, arg2 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeMethod", reflect.TypeOf((*MockActorManager)(nil).InvokeMethod), arg0, arg1, arg2)
 }
 
+// InvokeMethodWithCodec mocks base method.
+func (m *MockActorManager) InvokeMethodWithCodec(arg0, arg1, arg2 string, arg3 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvokeMethodWithCodec", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvokeMethodWithCodec indicates an expected call of InvokeMethodWithCodec.
+func (mr *MockActorManagerMockRecorder) InvokeMethodWithCodec(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeMethodWithCodec", reflect.TypeOf((*MockActorManager)(nil).InvokeMethodWithCodec), arg0, arg1, arg2, arg3)
+}
+
 // InvokeReminder mocks base method.
-func (m *MockActorManager) InvokeReminder(arg0, arg1 string, arg2 []byte) error.ActorErr {
+func (m *MockActorManager) InvokeReminder(arg0, arg1 string, arg2 []byte) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "InvokeReminder", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error.ActorErr)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
@@ -80,10 +94,10 @@ func (mr *MockActorManagerMockRecorder) InvokeReminder(arg0, arg1, arg2 interfac
 }
 
 // InvokeTimer mocks base method.
-func (m *MockActorManager) InvokeTimer(arg0, arg1 string, arg2 []byte) error.ActorErr {
+func (m *MockActorManager) InvokeTimer(arg0, arg1 string, arg2 []byte) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "InvokeTimer", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error.ActorErr)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
@@ -36,6 +36,18 @@ func (m *MockServer) EXPECT() *MockServerMockRecorder {
 	return m.recorder
 }
 
+// DiscardState mocks base method.
+func (m *MockServer) DiscardState() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DiscardState")
+}
+
+// DiscardState indicates an expected call of DiscardState.
+func (mr *MockServerMockRecorder) DiscardState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardState", reflect.TypeOf((*MockServer)(nil).DiscardState))
+}
+
 // ID mocks base method.
 func (m *MockServer) ID() string {
 	m.ctrl.T.Helper()
@@ -89,6 +101,18 @@ func (mr *MockServerMockRecorder) SetID(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetID", reflect.TypeOf((*MockServer)(nil).SetID), arg0)
 }
 
+// SetScheduler mocks base method.
+func (m *MockServer) SetScheduler(arg0 actor.Scheduler) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetScheduler", arg0)
+}
+
+// SetScheduler indicates an expected call of SetScheduler.
+func (mr *MockServerMockRecorder) SetScheduler(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetScheduler", reflect.TypeOf((*MockServer)(nil).SetScheduler), arg0)
+}
+
 // SetStateManager mocks base method.
 func (m *MockServer) SetStateManager(arg0 actor.StateManager) {
 	m.ctrl.T.Helper()
@@ -2,88 +2,391 @@ package runtime
 
 import (
 	"encoding/json"
+	"log"
 	"sync"
+	"time"
+
+	perrors "github.com/pkg/errors"
 
 	"github.com/dapr/go-sdk/actor"
 	"github.com/dapr/go-sdk/actor/api"
 	"github.com/dapr/go-sdk/actor/config"
 	actorErr "github.com/dapr/go-sdk/actor/error"
 	"github.com/dapr/go-sdk/actor/manager"
+	"github.com/dapr/go-sdk/actor/metrics"
+	"github.com/dapr/go-sdk/actor/state"
+	dapr "github.com/dapr/go-sdk/client"
 )
 
 type ActorRunTime struct {
 	config        api.ActorRuntimeConfig
+	configLock    sync.RWMutex
 	actorManagers sync.Map
+	// daprClient is shared by all registered actor types, the default client is used if nil
+	daprClient dapr.Client
+	// metrics records the metrics of all registered actor types, nothing is recorded if nil
+	metrics metrics.Recorder
+	// stateDump is set by EnableActorStateDump, for all registered actor types
+	stateDump bool
+	// ownedTypes are the actor types registered by RegisterActor, whose factory is not replaced by
+	// RegisterActorFactory
+	ownedTypes map[string]bool
 }
 
-var actorRuntimeInstance *ActorRunTime
+var (
+	actorRuntimeInstance *ActorRunTime
+	actorRuntimeOnce     sync.Once
+)
 
-// NewActorRuntime creates an empty ActorRuntime.
+// NewActorRuntime creates an empty ActorRuntime, whose actors use the default Dapr client.
 func NewActorRuntime() *ActorRunTime {
 	return &ActorRunTime{}
 }
 
-// GetActorRuntimeInstance gets or create runtime instance.
+// NewActorRuntimeWithClient creates an empty ActorRuntime, whose actors access their state through @daprClient.
+func NewActorRuntimeWithClient(daprClient dapr.Client) *ActorRunTime {
+	return &ActorRunTime{
+		daprClient: daprClient,
+	}
+}
+
+// GetActorRuntimeInstance gets or create the process wide runtime instance.
+// Deprecated: services own their ActorRunTime, create one with NewActorRuntime and pass it to the service instead.
 func GetActorRuntimeInstance() *ActorRunTime {
-	if actorRuntimeInstance == nil {
+	actorRuntimeOnce.Do(func() {
 		actorRuntimeInstance = NewActorRuntime()
-	}
+	})
 	return actorRuntimeInstance
 }
 
+// Configure sets the runtime level actor configuration of @opts, the actor idle timeout, scan interval, drain,
+// reentrancy and reminders storage partitions, which is served to Dapr by GetJSONSerializedConfig.
+// The previous runtime level configuration is overwritten, registered actor types are kept.
+func (r *ActorRunTime) Configure(opts ...config.Option) error {
+	conf := config.GetConfigFromOptions(opts...)
+	if err := conf.Validate(); err != nil {
+		return err
+	}
+	r.configLock.Lock()
+	defer r.configLock.Unlock()
+	r.config.ActorIdleTimeout = durationString(conf.ActorIdleTimeout)
+	r.config.ActorScanInterval = durationString(conf.ActorScanInterval)
+	r.config.DrainOngingCallTimeout = durationString(conf.DrainOngoingCallTimeout)
+	r.config.DrainBalancedActors = conf.DrainRebalancedActors
+	r.config.Reentrancy = reentrancyConfig(conf.Reentrancy)
+	r.config.RemindersStoragePartitions = conf.RemindersStoragePartitions
+	return nil
+}
+
+// RegisterActor registers @f as the factory of actor type @actorType. The serializer, state provider, idle timeout,
+// reentrancy and other options of @opts apply to this actor type, the runtime level configuration is overridden by
+// them in /dapr/config. @f is not called on registration unless client stubs are set by config.WithClientStubs, and
+// the actors it creates are activated as @actorType whatever their Type returns, so one implementation can be
+// registered as several types.
+// It fails if @actorType is registered already, @opts are invalid, or a client stub mismatches the actors.
+func (r *ActorRunTime) RegisterActor(actorType string, f actor.Factory, opts ...config.Option) error {
+	if actorType == "" {
+		return perrors.New("actor type required")
+	}
+	if f == nil {
+		return perrors.Errorf("actor factory of type %s required", actorType)
+	}
+	conf := config.GetConfigFromOptions(opts...)
+	if err := conf.Validate(); err != nil {
+		return perrors.Wrapf(err, "invalid options of actor type %s", actorType)
+	}
+	if err := validateClientStubs(actorType, f, conf); err != nil {
+		return perrors.Wrapf(err, "failed to register actor type %s", actorType)
+	}
+	r.configLock.Lock()
+	defer r.configLock.Unlock()
+	if _, ok := r.actorManagers.Load(actorType); ok {
+		return perrors.Errorf("actor type %s is registered already", actorType)
+	}
+	mng, err := manager.NewDefaultActorManagerWithType(actorType, conf, r.daprClient)
+	if err != nil {
+		return perrors.Wrapf(err, "failed to register actor type %s", actorType)
+	}
+	mng.RegisterActorImplFactory(f)
+	r.instrument(mng)
+	r.actorManagers.Store(actorType, mng)
+	if r.ownedTypes == nil {
+		r.ownedTypes = make(map[string]bool)
+	}
+	r.ownedTypes[actorType] = true
+	r.config.RegisteredActorTypes = append(r.config.RegisteredActorTypes, actorType)
+	r.setEntityConfig(actorType, conf)
+	return nil
+}
+
 // RegisterActorFactory registers the given actor factory from user, and create new actor manager if not exists.
+// The actor idle timeout, scan interval, drain, reentrancy and reminders storage partitions options of @opt override
+// the runtime level configuration for this actor type. The factory of an actor type registered by this function is
+// replaced, an actor type registered by RegisterActor is left as it is. @f is called once to get the actor type.
+// Deprecated: use RegisterActor, which doesn't create an actor to get its type and reports registration failures.
 func (r *ActorRunTime) RegisterActorFactory(f actor.Factory, opt ...config.Option) {
 	conf := config.GetConfigFromOptions(opt...)
-	actType := f().Type()
-	r.config.RegisteredActorTypes = append(r.config.RegisteredActorTypes, actType)
+	impl := f()
+	actType := impl.Type()
+	if err := conf.Validate(); err != nil {
+		log.Printf("failed to register actor type %s, err = %s", actType, err)
+		return
+	}
+	if err := validateClientStubs(actType, func() actor.Server { return impl }, conf); err != nil {
+		log.Printf("failed to register actor type %s, err = %s", actType, err)
+		return
+	}
+	r.configLock.Lock()
+	defer r.configLock.Unlock()
+	if r.ownedTypes[actType] {
+		log.Printf("failed to register actor type %s, err = it's registered already by RegisterActor", actType)
+		return
+	}
 	mng, ok := r.actorManagers.Load(actType)
 	if !ok {
-		newMng, err := manager.NewDefaultActorManager(conf.SerializerType)
-		if err != actorErr.Success {
+		// the manager is created with the type, so that it doesn't create another actor to get it
+		newMng, err := manager.NewDefaultActorManagerWithType(actType, conf, r.daprClient)
+		if err != nil {
+			log.Printf("failed to register actor type %s, err = %s", actType, err)
 			return
 		}
-		newMng.RegisterActorImplFactory(f)
-		r.actorManagers.Store(actType, newMng)
-		return
+		r.instrument(newMng)
+		mng, _ = r.actorManagers.LoadOrStore(actType, newMng)
 	}
 	mng.(manager.ActorManager).RegisterActorImplFactory(f)
+	if !containsString(r.config.RegisteredActorTypes, actType) {
+		r.config.RegisteredActorTypes = append(r.config.RegisteredActorTypes, actType)
+	}
+	r.setEntityConfig(actType, conf)
+}
+
+// validateClientStubs validates the client stubs of @conf against an actor of factory @f registered as @actorType, @f
+// is called only if there are client stubs.
+func validateClientStubs(actorType string, f actor.Factory, conf *config.ActorConfig) error {
+	if len(conf.ClientStubs) == 0 {
+		return nil
+	}
+	impl := f()
+	for _, stub := range conf.ClientStubs {
+		if err := manager.ValidateClientStubOfType(stub, actorType, impl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetMetricsRecorder sets the recorder of the metrics of all actor types, registered or registered later, to
+// @recorder, such as a metrics.PrometheusRecorder. Nothing is recorded by default.
+func (r *ActorRunTime) SetMetricsRecorder(recorder metrics.Recorder) {
+	r.configLock.Lock()
+	defer r.configLock.Unlock()
+	r.metrics = recorder
+	r.actorManagers.Range(func(key, value interface{}) bool {
+		r.instrument(value)
+		return true
+	})
+}
+
+// EnableActorStateDump makes the actors of all actor types, registered or registered later, keep the snapshot of
+// their states dumped by DumpActorState. It's disabled by default, so that the turns of actors don't pay for
+// encoding it, the actor admin API of the HTTP service enables it.
+func (r *ActorRunTime) EnableActorStateDump() {
+	r.configLock.Lock()
+	defer r.configLock.Unlock()
+	r.stateDump = true
+	r.actorManagers.Range(func(key, value interface{}) bool {
+		r.instrument(value)
+		return true
+	})
+}
+
+// instrument sets the metrics recorder of the runtime to actor manager @mng, and enables its state dump if the
+// runtime does. It must be called with configLock held.
+func (r *ActorRunTime) instrument(mng interface{}) {
+	if instrumented, ok := mng.(manager.Instrumented); ok {
+		instrumented.SetMetricsRecorder(r.metrics)
+	}
+	if inspector, ok := mng.(manager.ActorInspector); ok && r.stateDump {
+		inspector.EnableStateDump()
+	}
+}
+
+// setEntityConfig replaces the per actor type configuration of @actType with @conf, it must be called with configLock
+// held.
+func (r *ActorRunTime) setEntityConfig(actType string, conf *config.ActorConfig) {
+	entities := make([]*api.ActorEntityConfig, 0, len(r.config.EntitiesConfig))
+	for _, entity := range r.config.EntitiesConfig {
+		if len(entity.Entities) != 1 || entity.Entities[0] != actType {
+			entities = append(entities, entity)
+		}
+	}
+	if hasEntityOverrides(conf) {
+		entities = append(entities, &api.ActorEntityConfig{
+			Entities:                   []string{actType},
+			ActorIdleTimeout:           durationString(conf.ActorIdleTimeout),
+			ActorScanInterval:          durationString(conf.ActorScanInterval),
+			DrainOngoingCallTimeout:    durationString(conf.DrainOngoingCallTimeout),
+			DrainRebalancedActors:      conf.DrainRebalancedActors,
+			Reentrancy:                 reentrancyConfig(conf.Reentrancy),
+			RemindersStoragePartitions: conf.RemindersStoragePartitions,
+		})
+	}
+	if len(entities) == 0 {
+		entities = nil
+	}
+	r.config.EntitiesConfig = entities
 }
 
 func (r *ActorRunTime) GetJSONSerializedConfig() ([]byte, error) {
+	r.configLock.RLock()
+	defer r.configLock.RUnlock()
 	data, err := json.Marshal(&r.config)
 	return data, err
 }
 
-func (r *ActorRunTime) InvokeActorMethod(actorTypeName, actorID, actorMethod string, payload []byte) ([]byte, actorErr.ActorErr) {
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEntityOverrides(conf *config.ActorConfig) bool {
+	return conf.ActorIdleTimeout != 0 || conf.ActorScanInterval != 0 || conf.DrainOngoingCallTimeout != 0 ||
+		conf.DrainRebalancedActors || conf.Reentrancy != nil || conf.RemindersStoragePartitions != 0
+}
+
+// durationString formats @d as Dapr configuration duration, zero is left empty to use Dapr default.
+func durationString(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
+
+func reentrancyConfig(conf *config.ReentrancyConfig) *api.ActorReentrancyConfig {
+	if conf == nil {
+		return nil
+	}
+	reentrancy := &api.ActorReentrancyConfig{
+		Enabled: conf.Enabled,
+	}
+	if conf.MaxStackDepth > 0 {
+		maxStackDepth := conf.MaxStackDepth
+		reentrancy.MaxStackDepth = &maxStackDepth
+	}
+	return reentrancy
+}
+
+func (r *ActorRunTime) InvokeActorMethod(actorTypeName, actorID, actorMethod string, payload []byte) ([]byte, error) {
 	mng, ok := r.actorManagers.Load(actorTypeName)
 	if !ok {
-		return nil, actorErr.ErrActorTypeNotFound
+		return nil, actorErr.New(actorErr.ErrActorTypeNotFound, nil).WithActor(actorTypeName, actorID).WithMethod(actorMethod)
 	}
-	return mng.(manager.ActorManager).InvokeMethod(actorID, actorMethod, payload)
+	rspData, err := mng.(manager.ActorManager).InvokeMethod(actorID, actorMethod, payload)
+	return rspData, actorErr.WithActorType(err, actorTypeName)
 }
 
-func (r *ActorRunTime) Deactivate(actorTypeName, actorID string) actorErr.ActorErr {
+// InvokeActorMethodWithCodec invokes the actor method as InvokeActorMethod does, with @payload and the response
+// encoded by the codec named @codecName, which is declared by the caller, or by the codec the actor type is
+// registered with if it's empty.
+func (r *ActorRunTime) InvokeActorMethodWithCodec(actorTypeName, actorID, actorMethod, codecName string, payload []byte) ([]byte, error) {
+	mng, ok := r.actorManagers.Load(actorTypeName)
+	if !ok {
+		return nil, actorErr.New(actorErr.ErrActorTypeNotFound, nil).WithActor(actorTypeName, actorID).WithMethod(actorMethod)
+	}
+	rspData, err := mng.(manager.ActorManager).InvokeMethodWithCodec(actorID, actorMethod, codecName, payload)
+	return rspData, actorErr.WithActorType(err, actorTypeName)
+}
+
+// InvokeActorMethodWithReentrancy invokes the actor method as InvokeActorMethodWithCodec does, in the reentrant call
+// chain @reentrancyID Dapr sends, so that the call enters the actor while the chain holds it rather than waiting for
+// the chain to end. @reentrancyID is ignored by the managers which don't implement manager.ReentrantInvoker.
+func (r *ActorRunTime) InvokeActorMethodWithReentrancy(reentrancyID, actorTypeName, actorID, actorMethod, codecName string, payload []byte) ([]byte, error) {
+	mng, ok := r.actorManagers.Load(actorTypeName)
+	if !ok {
+		return nil, actorErr.New(actorErr.ErrActorTypeNotFound, nil).WithActor(actorTypeName, actorID).WithMethod(actorMethod)
+	}
+	invoker, ok := mng.(manager.ReentrantInvoker)
+	if !ok {
+		rspData, err := mng.(manager.ActorManager).InvokeMethodWithCodec(actorID, actorMethod, codecName, payload)
+		return rspData, actorErr.WithActorType(err, actorTypeName)
+	}
+	rspData, err := invoker.InvokeMethodWithReentrancy(reentrancyID, actorID, actorMethod, codecName, payload)
+	return rspData, actorErr.WithActorType(err, actorTypeName)
+}
+
+func (r *ActorRunTime) Deactivate(actorTypeName, actorID string) error {
 	targetManager, ok := r.actorManagers.Load(actorTypeName)
 	if !ok {
-		return actorErr.ErrActorTypeNotFound
+		return actorErr.New(actorErr.ErrActorTypeNotFound, nil).WithActor(actorTypeName, actorID)
 	}
-	return targetManager.(manager.ActorManager).DetectiveActor(actorID)
+	return actorErr.WithActorType(targetManager.(manager.ActorManager).DetectiveActor(actorID), actorTypeName)
 }
 
-func (r *ActorRunTime) InvokeReminder(actorTypeName, actorID, reminderName string, params []byte) actorErr.ActorErr {
+func (r *ActorRunTime) InvokeReminder(actorTypeName, actorID, reminderName string, params []byte) error {
 	targetManager, ok := r.actorManagers.Load(actorTypeName)
 	if !ok {
-		return actorErr.ErrActorTypeNotFound
+		return actorErr.New(actorErr.ErrActorTypeNotFound, nil).WithActor(actorTypeName, actorID).WithMethod(reminderName)
 	}
 	mng := targetManager.(manager.ActorManager)
-	return mng.InvokeReminder(actorID, reminderName, params)
+	return actorErr.WithActorType(mng.InvokeReminder(actorID, reminderName, params), actorTypeName)
 }
 
-func (r *ActorRunTime) InvokeTimer(actorTypeName, actorID, timerName string, params []byte) actorErr.ActorErr {
+func (r *ActorRunTime) InvokeTimer(actorTypeName, actorID, timerName string, params []byte) error {
 	targetManager, ok := r.actorManagers.Load(actorTypeName)
 	if !ok {
-		return actorErr.ErrActorTypeNotFound
+		return actorErr.New(actorErr.ErrActorTypeNotFound, nil).WithActor(actorTypeName, actorID).WithMethod(timerName)
 	}
 	mng := targetManager.(manager.ActorManager)
-	return mng.InvokeTimer(actorID, timerName, params)
+	return actorErr.WithActorType(mng.InvokeTimer(actorID, timerName, params), actorTypeName)
+}
+
+// ActiveActors returns the sorted IDs of the active actors per actor type, for debugging. Actor types whose manager
+// doesn't implement manager.ActorInspector are left out.
+func (r *ActorRunTime) ActiveActors() map[string][]string {
+	activeActors := make(map[string][]string)
+	r.actorManagers.Range(func(key, value interface{}) bool {
+		if inspector, ok := value.(manager.ActorInspector); ok {
+			activeActors[key.(string)] = inspector.ActiveActorIDs()
+		}
+		return true
+	})
+	return activeActors
+}
+
+// DumpActorState returns the states in the cache of the active actor @actorID of type @actorTypeName, as they were at
+// the end of its last turn, for debugging. It's empty unless EnableActorStateDump is called.
+func (r *ActorRunTime) DumpActorState(actorTypeName, actorID string) ([]state.CachedState, error) {
+	inspector, err := r.inspector(actorTypeName, actorID)
+	if err != nil {
+		return nil, err
+	}
+	states, err := inspector.DumpState(actorID)
+	return states, actorErr.WithActorType(err, actorTypeName)
+}
+
+// GetPersistedActorState reads the state @stateName of actor @actorID of type @actorTypeName as persisted by Dapr,
+// for debugging. The actor doesn't need to be active.
+func (r *ActorRunTime) GetPersistedActorState(actorTypeName, actorID, stateName string) ([]byte, error) {
+	inspector, err := r.inspector(actorTypeName, actorID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := inspector.GetPersistedState(actorID, stateName)
+	return data, actorErr.WithActorType(err, actorTypeName)
+}
+
+func (r *ActorRunTime) inspector(actorTypeName, actorID string) (manager.ActorInspector, error) {
+	targetManager, ok := r.actorManagers.Load(actorTypeName)
+	if !ok {
+		return nil, actorErr.New(actorErr.ErrActorTypeNotFound, nil).WithActor(actorTypeName, actorID)
+	}
+	inspector, ok := targetManager.(manager.ActorInspector)
+	if !ok {
+		return nil, perrors.Errorf("actor manager %T of type %s can't be inspected", targetManager, actorTypeName)
+	}
+	return inspector, nil
 }
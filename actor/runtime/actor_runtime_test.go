@@ -1,9 +1,17 @@
 package runtime
 
 import (
+	"bytes"
+	"context"
 	"testing"
+	"time"
+
+	"github.com/dapr/go-sdk/actor"
+	"github.com/dapr/go-sdk/actor/config"
+	stateConstant "github.com/dapr/go-sdk/actor/state/constant"
 
 	actorErr "github.com/dapr/go-sdk/actor/error"
+	"github.com/dapr/go-sdk/actor/metrics"
 	actorMock "github.com/dapr/go-sdk/actor/mock"
 
 	"github.com/golang/mock/gomock"
@@ -26,7 +34,7 @@ func TestRegisterActorFactoryAndInvokeMethod(t *testing.T) {
 	defer ctrl.Finish()
 
 	_, err := rt.InvokeActorMethod("testActorType", "mockActorID", "Invoke", []byte("param"))
-	assert.Equal(t, actorErr.ErrActorTypeNotFound, err)
+	assert.ErrorIs(t, err, actorErr.ErrActorTypeNotFound)
 
 	mockServer := actorMock.NewMockActorManager(ctrl)
 	rt.actorManagers.Store("testActorType", mockServer)
@@ -34,11 +42,34 @@ func TestRegisterActorFactoryAndInvokeMethod(t *testing.T) {
 	mockServer.EXPECT().RegisterActorImplFactory(gomock.Any())
 	rt.RegisterActorFactory(actorMock.ActorImplFactory)
 
-	mockServer.EXPECT().InvokeMethod("mockActorID", "Invoke", []byte("param")).Return([]byte("response"), actorErr.Success)
+	mockServer.EXPECT().InvokeMethod("mockActorID", "Invoke", []byte("param")).Return([]byte("response"), nil)
 	rspData, err := rt.InvokeActorMethod("testActorType", "mockActorID", "Invoke", []byte("param"))
 
 	assert.Equal(t, []byte("response"), rspData)
-	assert.Equal(t, actorErr.Success, err)
+	assert.NoError(t, err)
+}
+
+func TestInvokeActorMethodWithReentrancy(t *testing.T) {
+	rt := NewActorRuntime()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, err := rt.InvokeActorMethodWithReentrancy("chain-1", "testActorType", "mockActorID", "Invoke", "", []byte("param"))
+	assert.ErrorIs(t, err, actorErr.ErrActorTypeNotFound)
+
+	// the reentrancy ID is dropped by managers which don't run the turns one at a time
+	mockServer := actorMock.NewMockActorManager(ctrl)
+	rt.actorManagers.Store("testActorType", mockServer)
+	mockServer.EXPECT().InvokeMethodWithCodec("mockActorID", "Invoke", "json", []byte("param")).Return([]byte("response"), nil)
+	rspData, err := rt.InvokeActorMethodWithReentrancy("chain-1", "testActorType", "mockActorID", "Invoke", "json", []byte("param"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("response"), rspData)
+
+	rt = NewActorRuntime()
+	rt.RegisterActorFactory(actorMock.ActorImplFactory, config.WithStateProviderName(stateConstant.MemoryStateProviderName))
+	rspData, err = rt.InvokeActorMethodWithReentrancy("chain-1", "testActorType", "mockActorID", "Invoke", "", []byte(`"hello"`))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`"hello"`), rspData)
 }
 
 func TestDeactive(t *testing.T) {
@@ -47,7 +78,7 @@ func TestDeactive(t *testing.T) {
 	defer ctrl.Finish()
 
 	err := rt.Deactivate("testActorType", "mockActorID")
-	assert.Equal(t, actorErr.ErrActorTypeNotFound, err)
+	assert.ErrorIs(t, err, actorErr.ErrActorTypeNotFound)
 
 	mockServer := actorMock.NewMockActorManager(ctrl)
 	rt.actorManagers.Store("testActorType", mockServer)
@@ -55,10 +86,10 @@ func TestDeactive(t *testing.T) {
 	mockServer.EXPECT().RegisterActorImplFactory(gomock.Any())
 	rt.RegisterActorFactory(actorMock.ActorImplFactory)
 
-	mockServer.EXPECT().DetectiveActor("mockActorID").Return(actorErr.Success)
+	mockServer.EXPECT().DetectiveActor("mockActorID").Return(nil)
 	err = rt.Deactivate("testActorType", "mockActorID")
 
-	assert.Equal(t, actorErr.Success, err)
+	assert.NoError(t, err)
 }
 
 func TestInvokeReminder(t *testing.T) {
@@ -67,7 +98,7 @@ func TestInvokeReminder(t *testing.T) {
 	defer ctrl.Finish()
 
 	err := rt.InvokeReminder("testActorType", "mockActorID", "mockReminder", []byte("param"))
-	assert.Equal(t, actorErr.ErrActorTypeNotFound, err)
+	assert.ErrorIs(t, err, actorErr.ErrActorTypeNotFound)
 
 	mockServer := actorMock.NewMockActorManager(ctrl)
 	rt.actorManagers.Store("testActorType", mockServer)
@@ -75,10 +106,10 @@ func TestInvokeReminder(t *testing.T) {
 	mockServer.EXPECT().RegisterActorImplFactory(gomock.Any())
 	rt.RegisterActorFactory(actorMock.ActorImplFactory)
 
-	mockServer.EXPECT().InvokeReminder("mockActorID", "mockReminder", []byte("param")).Return(actorErr.Success)
+	mockServer.EXPECT().InvokeReminder("mockActorID", "mockReminder", []byte("param")).Return(nil)
 	err = rt.InvokeReminder("testActorType", "mockActorID", "mockReminder", []byte("param"))
 
-	assert.Equal(t, actorErr.Success, err)
+	assert.NoError(t, err)
 }
 
 func TestInvokeTimer(t *testing.T) {
@@ -87,7 +118,7 @@ func TestInvokeTimer(t *testing.T) {
 	defer ctrl.Finish()
 
 	err := rt.InvokeTimer("testActorType", "mockActorID", "mockTimer", []byte("param"))
-	assert.Equal(t, actorErr.ErrActorTypeNotFound, err)
+	assert.ErrorIs(t, err, actorErr.ErrActorTypeNotFound)
 
 	mockServer := actorMock.NewMockActorManager(ctrl)
 	rt.actorManagers.Store("testActorType", mockServer)
@@ -95,8 +126,181 @@ func TestInvokeTimer(t *testing.T) {
 	mockServer.EXPECT().RegisterActorImplFactory(gomock.Any())
 	rt.RegisterActorFactory(actorMock.ActorImplFactory)
 
-	mockServer.EXPECT().InvokeTimer("mockActorID", "mockTimer", []byte("param")).Return(actorErr.Success)
+	mockServer.EXPECT().InvokeTimer("mockActorID", "mockTimer", []byte("param")).Return(nil)
 	err = rt.InvokeTimer("testActorType", "mockActorID", "mockTimer", []byte("param"))
 
-	assert.Equal(t, actorErr.Success, err)
+	assert.NoError(t, err)
+}
+
+func TestConfigure(t *testing.T) {
+	rt := NewActorRuntime()
+
+	data, err := rt.GetJSONSerializedConfig()
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"entities":null,"drainRebalancedActors":false}`, string(data))
+
+	assert.Error(t, rt.Configure(config.WithActorIdleTimeout(-time.Second)))
+	assert.Nil(t, rt.Configure(
+		config.WithActorIdleTimeout(time.Hour),
+		config.WithActorScanInterval(30*time.Second),
+		config.WithDrainOngoingCallTimeout(time.Minute),
+		config.WithDrainRebalancedActors(true),
+		config.WithReentrancy(0),
+		config.WithRemindersStoragePartitions(7),
+	))
+	rt.RegisterActorFactory(actorMock.ActorImplFactory)
+	rt.RegisterActorFactory(actorMock.NotReminderCalleeActorFactory, config.WithActorIdleTimeout(time.Minute), config.WithReentrancy(8))
+	// invalid options of actor type are not registered
+	rt.RegisterActorFactory(actorMock.NotReminderCalleeActorFactory, config.WithRemindersStoragePartitions(-1))
+
+	data, err = rt.GetJSONSerializedConfig()
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"entities": ["testActorType", "testActorNotReminderCalleeType"],
+		"actorIdleTimeout": "1h0m0s",
+		"actorScanInterval": "30s",
+		"drainOngoingCallTimeout": "1m0s",
+		"drainRebalancedActors": true,
+		"reentrancy": {"enabled": true},
+		"remindersStoragePartitions": 7,
+		"entitiesConfig": [{
+			"entities": ["testActorNotReminderCalleeType"],
+			"actorIdleTimeout": "1m0s",
+			"reentrancy": {"enabled": true, "maxStackDepth": 8}
+		}]
+	}`, string(data))
+}
+
+type validStub struct {
+	Invoke func(context.Context, string) (string, error)
+}
+
+func (s *validStub) Type() string { return "actorTypeC" }
+func (s *validStub) ID() string   { return "testActorID" }
+
+type invalidStub struct {
+	Invoke func(context.Context, int) (string, error)
+}
+
+func (s *invalidStub) Type() string { return "actorTypeC" }
+func (s *invalidStub) ID() string   { return "testActorID" }
+
+func TestRegisterActor(t *testing.T) {
+	rt := NewActorRuntime()
+	memory := config.WithStateProviderName(stateConstant.MemoryStateProviderName)
+
+	// one implementation is registered as several actor types
+	assert.Nil(t, rt.RegisterActor("actorTypeA", actorMock.ActorImplFactory, memory))
+	assert.Nil(t, rt.RegisterActor("actorTypeB", actorMock.ActorImplFactory, memory, config.WithActorIdleTimeout(time.Minute)))
+	rspData, err := rt.InvokeActorMethod("actorTypeA", "mockActorID", "Invoke", []byte(`"hello"`))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`"hello"`), rspData)
+	_, err = rt.InvokeActorMethod("actorTypeB", "mockActorID", "Invoke", []byte(`"hello"`))
+	assert.NoError(t, err)
+
+	assert.Error(t, rt.RegisterActor("actorTypeA", actorMock.ActorImplFactory))
+	assert.Error(t, rt.RegisterActor("", actorMock.ActorImplFactory))
+	assert.Error(t, rt.RegisterActor("actorTypeC", nil))
+	assert.Error(t, rt.RegisterActor("actorTypeC", actorMock.ActorImplFactory, config.WithActorIdleTimeout(-time.Second)))
+	assert.Error(t, rt.RegisterActor("actorTypeC", actorMock.ActorImplFactory, config.WithStateProviderName("unknown")))
+	// client stubs are validated against the actors of the type
+	assert.Error(t, rt.RegisterActor("actorTypeC", actorMock.ActorImplFactory, config.WithClientStubs(&invalidStub{})))
+	assert.Nil(t, rt.RegisterActor("actorTypeC", actorMock.ActorImplFactory, memory, config.WithClientStubs(&validStub{})))
+	// the deprecated registration replaces the factory of the type, without registering the type again
+	rt.RegisterActorFactory(actorMock.ActorImplFactory)
+	rt.RegisterActorFactory(actorMock.ActorImplFactory)
+
+	data, err := rt.GetJSONSerializedConfig()
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"entities": ["actorTypeA", "actorTypeB", "actorTypeC", "testActorType"],
+		"drainRebalancedActors": false,
+		"entitiesConfig": [{
+			"entities": ["actorTypeB"],
+			"actorIdleTimeout": "1m0s"
+		}]
+	}`, string(data))
+}
+
+func TestSetMetricsRecorder(t *testing.T) {
+	rt := NewActorRuntime()
+	memory := config.WithStateProviderName(stateConstant.MemoryStateProviderName)
+	assert.Nil(t, rt.RegisterActor("actorTypeA", actorMock.ActorImplFactory, memory))
+
+	// the recorder applies to the actor types registered before and after it's set
+	recorder := metrics.NewPrometheusRecorder()
+	rt.SetMetricsRecorder(recorder)
+	assert.Nil(t, rt.RegisterActor("actorTypeB", actorMock.ActorImplFactory, memory))
+	for _, actorType := range []string{"actorTypeA", "actorTypeB"} {
+		_, err := rt.InvokeActorMethod(actorType, "mockActorID", "Invoke", []byte(`"hello"`))
+		assert.NoError(t, err)
+	}
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, recorder.Write(buf))
+	assert.Contains(t, buf.String(), `dapr_actor_activations_total{actor_type="actorTypeA"} 1`)
+	assert.Contains(t, buf.String(), `dapr_actor_activations_total{actor_type="actorTypeB"} 1`)
+	assert.Contains(t, buf.String(), `dapr_actor_turn_duration_seconds_count{actor_type="actorTypeB",kind="method",status="success"} 1`)
+}
+
+func TestRegisterActorFactory(t *testing.T) {
+	memory := config.WithStateProviderName(stateConstant.MemoryStateProviderName)
+	calls := map[string]int{}
+	countingFactory := func(name string) actor.Factory {
+		return func() actor.Server {
+			calls[name]++
+			return actorMock.ActorImplFactory()
+		}
+	}
+
+	// the deprecated registration creates one actor to get the type
+	rt := NewActorRuntime()
+	rt.RegisterActorFactory(countingFactory("deprecated"), memory)
+	assert.Equal(t, 1, calls["deprecated"])
+	_, err := rt.InvokeActorMethod("testActorType", "mockActorID", "Invoke", []byte(`"hello"`))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls["deprecated"])
+
+	// it doesn't replace the factory of a type registered by RegisterActor
+	rt = NewActorRuntime()
+	assert.Nil(t, rt.RegisterActor("testActorType", countingFactory("owner"), memory))
+	rt.RegisterActorFactory(countingFactory("replacement"), memory)
+	_, err = rt.InvokeActorMethod("testActorType", "mockActorID", "Invoke", []byte(`"hello"`))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls["owner"])
+	assert.Equal(t, 1, calls["replacement"])
+	assert.Equal(t, []string{"testActorType"}, rt.config.RegisteredActorTypes)
+}
+
+func TestRegisterActorFactoryConcurrently(t *testing.T) {
+	rt := NewActorRuntime()
+	memory := config.WithStateProviderName(stateConstant.MemoryStateProviderName)
+	managers := make(chan interface{}, 10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			rt.RegisterActorFactory(actorMock.ActorImplFactory, memory)
+			mng, _ := rt.actorManagers.Load("testActorType")
+			managers <- mng
+		}()
+	}
+
+	// a single manager is created, and the type is registered once
+	mng := <-managers
+	for i := 1; i < 10; i++ {
+		assert.Same(t, mng, <-managers)
+	}
+	assert.Equal(t, []string{"testActorType"}, rt.config.RegisteredActorTypes)
+}
+
+func TestGetActorRuntimeConcurrently(t *testing.T) {
+	instances := make(chan *ActorRunTime, 10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			instances <- GetActorRuntimeInstance()
+		}()
+	}
+	rt := <-instances
+	for i := 1; i < 10; i++ {
+		assert.Same(t, rt, <-instances)
+	}
 }
@@ -0,0 +1,219 @@
+package actor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	perrors "github.com/pkg/errors"
+
+	"github.com/dapr/go-sdk/actor/codec"
+)
+
+// Schedule is when a reminder or timer is fired: first after DueTime, then every Period. A zero Period means it's
+// fired once, a positive Repetitions limits the number of times it's fired, and a positive TTL expires it after TTL
+// since registration.
+type Schedule struct {
+	DueTime     time.Duration
+	Period      time.Duration
+	Repetitions int
+	TTL         time.Duration
+}
+
+// NewSchedule creates the schedule fired after @dueTime and then every @period.
+func NewSchedule(dueTime, period time.Duration) *Schedule {
+	return &Schedule{
+		DueTime: dueTime,
+		Period:  period,
+	}
+}
+
+// WithRepetitions limits the schedule to be fired @repetitions times, the period is formatted as ISO 8601
+// repetition, e.g. R5/PT10S.
+func (s *Schedule) WithRepetitions(repetitions int) *Schedule {
+	s.Repetitions = repetitions
+	return s
+}
+
+// WithTTL expires the schedule @ttl after it's registered.
+func (s *Schedule) WithTTL(ttl time.Duration) *Schedule {
+	s.TTL = ttl
+	return s
+}
+
+// Validate checks the schedule can be registered to Dapr.
+func (s *Schedule) Validate() error {
+	switch {
+	case s.DueTime < 0:
+		return perrors.Errorf("invalid schedule, negative due time %s", s.DueTime)
+	case s.Period < 0:
+		return perrors.Errorf("invalid schedule, negative period %s", s.Period)
+	case s.Repetitions < 0:
+		return perrors.Errorf("invalid schedule, negative repetitions %d", s.Repetitions)
+	case s.Repetitions > 0 && s.Period == 0:
+		return perrors.Errorf("invalid schedule, repetitions %d without period", s.Repetitions)
+	case s.TTL < 0:
+		return perrors.Errorf("invalid schedule, negative ttl %s", s.TTL)
+	case s.TTL > 0 && s.TTL <= s.DueTime:
+		return perrors.Errorf("invalid schedule, ttl %s expires before due time %s", s.TTL, s.DueTime)
+	}
+	return nil
+}
+
+// Format validates the schedule and formats it as the due time, period and ttl of Dapr reminders and timers.
+// Durations are formatted as Go durations, and the period with repetitions as ISO 8601 repetition.
+func (s *Schedule) Format() (dueTime, period, ttl string, err error) {
+	if err := s.Validate(); err != nil {
+		return "", "", "", err
+	}
+	dueTime = s.DueTime.String()
+	switch {
+	case s.Repetitions > 0:
+		period = fmt.Sprintf("R%d/%s", s.Repetitions, FormatISO8601Duration(s.Period))
+	case s.Period > 0:
+		period = s.Period.String()
+	}
+	if s.TTL > 0 {
+		ttl = s.TTL.String()
+	}
+	return dueTime, period, ttl, nil
+}
+
+// ParseSchedule parses the due time, period and ttl of Dapr reminders and timers. The due time and ttl are Go
+// durations, ISO 8601 durations or RFC 3339 times, the period is a Go duration, an ISO 8601 duration or an ISO 8601
+// repetition as R5/PT10S. Empty strings are parsed as zero.
+func ParseSchedule(dueTime, period, ttl string) (*Schedule, error) {
+	schedule := &Schedule{}
+	var err error
+	if schedule.DueTime, err = parseScheduleTime(dueTime); err != nil {
+		return nil, perrors.Wrapf(err, "invalid due time %s", dueTime)
+	}
+	if schedule.TTL, err = parseScheduleTime(ttl); err != nil {
+		return nil, perrors.Wrapf(err, "invalid ttl %s", ttl)
+	}
+	if schedule.Period, schedule.Repetitions, err = parsePeriod(period); err != nil {
+		return nil, perrors.Wrapf(err, "invalid period %s", period)
+	}
+	return schedule, nil
+}
+
+// ValidateSchedule checks the due time, period and ttl of Dapr reminders and timers are well formed, as
+// ParseSchedule parses them, and valid.
+func ValidateSchedule(dueTime, period, ttl string) error {
+	schedule, err := ParseSchedule(dueTime, period, ttl)
+	if err != nil {
+		return err
+	}
+	return schedule.Validate()
+}
+
+// Scheduler registers the reminders and timers of one actor instance, it is injected into ServerImplBase by the
+// actor manager, which fills in the type and ID of the actor.
+type Scheduler interface {
+	// RegisterReminder registers reminder @name fired as @schedule, @data is encoded with the serializer of the actor.
+	RegisterReminder(ctx context.Context, name string, schedule *Schedule, data interface{}) error
+	// UnregisterReminder unregisters reminder @name.
+	UnregisterReminder(ctx context.Context, name string) error
+	// RegisterTimer registers timer @name, which invokes actor method @callback as @schedule. @data is encoded with
+	// the serializer of the actor, and decoded as the argument of @callback.
+	RegisterTimer(ctx context.Context, name string, schedule *Schedule, callback string, data interface{}) error
+	// UnregisterTimer unregisters timer @name.
+	UnregisterTimer(ctx context.Context, name string) error
+}
+
+// ErrScheduleDone is returned, wrapped or not, by a reminder handler or timer callback which is done, so that the
+// reminder or timer is unregistered.
+var ErrScheduleDone = perrors.New("actor reminder or timer is done")
+
+// Reminder is a reminder received by an actor.
+type Reminder struct {
+	Name        string
+	DueTime     time.Duration
+	Period      time.Duration
+	Repetitions int
+	TTL         time.Duration
+	// Data is the encoded data the reminder is registered with, decode it with Decode.
+	Data []byte
+
+	serializer codec.Codec
+}
+
+// NewReminder creates reminder @name received by an actor as @schedule, whose @data is encoded with @serializer.
+func NewReminder(name string, schedule *Schedule, data []byte, serializer codec.Codec) *Reminder {
+	return &Reminder{
+		Name:        name,
+		DueTime:     schedule.DueTime,
+		Period:      schedule.Period,
+		Repetitions: schedule.Repetitions,
+		TTL:         schedule.TTL,
+		Data:        data,
+		serializer:  serializer,
+	}
+}
+
+// Decode decodes the data of the reminder into @v.
+func (r *Reminder) Decode(v interface{}) error {
+	if r.serializer == nil {
+		return perrors.New("reminder has no serializer")
+	}
+	return r.serializer.Unmarshal(r.Data, v)
+}
+
+// ReminderHandler is impl by actors receiving reminders registered with ServerImplBase.RegisterReminder.
+// It's preferred over ReminderCallee if an actor impls both. Returning ErrScheduleDone unregisters the reminder.
+type ReminderHandler interface {
+	HandleReminder(ctx context.Context, reminder *Reminder) error
+}
+
+// errSchedulerNotSet is returned by the reminder and timer helpers of an actor not created by the actor manager.
+var errSchedulerNotSet = perrors.New("scheduler of the actor is not set")
+
+// SetScheduler is to inject the Scheduler of this actor instance, it's called by the actor manager.
+func (b *ServerImplBase) SetScheduler(scheduler Scheduler) {
+	b.scheduler = scheduler
+}
+
+// RegisterReminder registers reminder @name of this actor instance, which is fired after @dueTime and then every
+// @period, a zero @period means it's fired once. @data is encoded with the serializer of the actor.
+func (b *ServerImplBase) RegisterReminder(ctx context.Context, name string, dueTime, period time.Duration, data interface{}) error {
+	return b.ScheduleReminder(ctx, name, NewSchedule(dueTime, period), data)
+}
+
+// ScheduleReminder registers reminder @name of this actor instance fired as @schedule.
+func (b *ServerImplBase) ScheduleReminder(ctx context.Context, name string, schedule *Schedule, data interface{}) error {
+	if b.scheduler == nil {
+		return errSchedulerNotSet
+	}
+	return b.scheduler.RegisterReminder(ctx, name, schedule, data)
+}
+
+// UnregisterReminder unregisters reminder @name of this actor instance.
+func (b *ServerImplBase) UnregisterReminder(ctx context.Context, name string) error {
+	if b.scheduler == nil {
+		return errSchedulerNotSet
+	}
+	return b.scheduler.UnregisterReminder(ctx, name)
+}
+
+// RegisterTimer registers timer @name of this actor instance, which invokes actor method @callback after @dueTime
+// and then every @period, a zero @period means it's fired once. @data is encoded with the serializer of the actor,
+// and decoded as the argument of @callback.
+func (b *ServerImplBase) RegisterTimer(ctx context.Context, name string, dueTime, period time.Duration, callback string, data interface{}) error {
+	return b.ScheduleTimer(ctx, name, NewSchedule(dueTime, period), callback, data)
+}
+
+// ScheduleTimer registers timer @name of this actor instance, which invokes actor method @callback as @schedule.
+func (b *ServerImplBase) ScheduleTimer(ctx context.Context, name string, schedule *Schedule, callback string, data interface{}) error {
+	if b.scheduler == nil {
+		return errSchedulerNotSet
+	}
+	return b.scheduler.RegisterTimer(ctx, name, schedule, callback, data)
+}
+
+// UnregisterTimer unregisters timer @name of this actor instance.
+func (b *ServerImplBase) UnregisterTimer(ctx context.Context, name string) error {
+	if b.scheduler == nil {
+		return errSchedulerNotSet
+	}
+	return b.scheduler.UnregisterTimer(ctx, name)
+}
@@ -0,0 +1,105 @@
+package actor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		schedule    *Schedule
+		wantDueTime string
+		wantPeriod  string
+		wantTTL     string
+		wantErr     bool
+	}{
+		{
+			name:        "once",
+			schedule:    NewSchedule(5*time.Second, 0),
+			wantDueTime: "5s",
+		},
+		{
+			name:        "periodic",
+			schedule:    NewSchedule(0, time.Minute),
+			wantDueTime: "0s",
+			wantPeriod:  "1m0s",
+		},
+		{
+			name:        "repetitions and ttl",
+			schedule:    NewSchedule(time.Second, 90*time.Second).WithRepetitions(5).WithTTL(time.Hour),
+			wantDueTime: "1s",
+			wantPeriod:  "R5/PT1M30S",
+			wantTTL:     "1h0m0s",
+		},
+		{
+			name:     "negative due time",
+			schedule: NewSchedule(-time.Second, 0),
+			wantErr:  true,
+		},
+		{
+			name:     "repetitions without period",
+			schedule: NewSchedule(0, 0).WithRepetitions(3),
+			wantErr:  true,
+		},
+		{
+			name:     "ttl before due time",
+			schedule: NewSchedule(time.Minute, time.Second).WithTTL(time.Second),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dueTime, period, ttl, err := tt.schedule.Format()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDueTime, dueTime)
+			assert.Equal(t, tt.wantPeriod, period)
+			assert.Equal(t, tt.wantTTL, ttl)
+
+			// formatted schedules are parsed back
+			schedule, err := ParseSchedule(dueTime, period, ttl)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.schedule, schedule)
+		})
+	}
+}
+
+func TestParseSchedule(t *testing.T) {
+	schedule, err := ParseSchedule("PT10S", "R3/P1DT2H", "P1W")
+	assert.NoError(t, err)
+	assert.Equal(t, &Schedule{
+		DueTime:     10 * time.Second,
+		Period:      26 * time.Hour,
+		Repetitions: 3,
+		TTL:         7 * 24 * time.Hour,
+	}, schedule)
+
+	schedule, err = ParseSchedule("", "PT0.5S", "")
+	assert.NoError(t, err)
+	assert.Equal(t, &Schedule{Period: 500 * time.Millisecond}, schedule)
+
+	schedule, err = ParseSchedule(time.Now().Add(time.Hour).Format(time.RFC3339), "", "")
+	assert.NoError(t, err)
+	assert.InDelta(t, float64(time.Hour), float64(schedule.DueTime), float64(time.Minute))
+
+	for _, invalid := range [][3]string{
+		{"1 second", "", ""},
+		{"", "P", ""},
+		{"", "PT", ""},
+		{"", "R/PT10S", ""},
+		{"", "RX/PT10S", ""},
+		{"", "", "P1H"},
+	} {
+		_, err := ParseSchedule(invalid[0], invalid[1], invalid[2])
+		assert.Error(t, err, invalid)
+	}
+
+	assert.NoError(t, ValidateSchedule("5s", "R5/PT10S", "1m"))
+	assert.Error(t, ValidateSchedule("5s", "-1s", ""))
+}
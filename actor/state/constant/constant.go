@@ -0,0 +1,7 @@
+package constant
+
+// DefaultStateProviderName is default actor state provider backed by the Dapr sidecar.
+const DefaultStateProviderName = "dapr"
+
+// MemoryStateProviderName is actor state provider which keeps states in process memory.
+const MemoryStateProviderName = "memory"
@@ -0,0 +1,115 @@
+package state
+
+import (
+	"sync"
+
+	perrors "github.com/pkg/errors"
+
+	"github.com/dapr/go-sdk/actor/codec"
+	"github.com/dapr/go-sdk/actor/codec/constant"
+)
+
+// MemoryStateProvider keeps actor states in process memory, it's meant for unit tests and local development, the
+// states are lost when the process exits.
+type MemoryStateProvider struct {
+	lock sync.RWMutex
+	// states stores the map actorType/actorID -> stateName -> encoded state
+	states          map[string]map[string][]byte
+	stateSerializer codec.Codec
+}
+
+// NewMemoryStateProvider creates an empty in-memory state provider.
+func NewMemoryStateProvider() *MemoryStateProvider {
+	stateSerializer, _ := codec.GetActorCodec(constant.DefaultSerializerType)
+	return &MemoryStateProvider{
+		states:          make(map[string]map[string][]byte),
+		stateSerializer: stateSerializer,
+	}
+}
+
+func (m *MemoryStateProvider) Contains(actorType, actorID, stateName string) (bool, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	_, ok := m.states[actorKey(actorType, actorID)][stateName]
+	return ok, nil
+}
+
+func (m *MemoryStateProvider) Load(actorType, actorID, stateName string, reply interface{}) error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.load(actorKey(actorType, actorID), stateName, reply)
+}
+
+func (m *MemoryStateProvider) LoadBatch(actorType, actorID string, replies map[string]interface{}) error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	key := actorKey(actorType, actorID)
+	for stateName, reply := range replies {
+		if err := m.load(key, stateName, reply); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MemoryStateProvider) load(key, stateName string, reply interface{}) error {
+	data, ok := m.states[key][stateName]
+	if !ok {
+		return perrors.Wrapf(ErrStateNotFound, "state %s of actor %s", stateName, key)
+	}
+	if err := m.stateSerializer.Unmarshal(data, reply); err != nil {
+		return perrors.Errorf("unmarshal state data error = %s", err.Error())
+	}
+	return nil
+}
+
+func (m *MemoryStateProvider) ListStateNames(actorType, actorID string) ([]string, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	names := make([]string, 0)
+	for stateName := range m.states[actorKey(actorType, actorID)] {
+		names = append(names, stateName)
+	}
+	return names, nil
+}
+
+func (m *MemoryStateProvider) Apply(actorType, actorID string, changes []*ActorStateChange) error {
+	// encode all values before touching the states, so that the changes are applied all or nothing.
+	values := make(map[string][]byte, len(changes))
+	for _, stateChange := range changes {
+		if stateChange == nil || (stateChange.changeKind != Add && stateChange.changeKind != Update) {
+			continue
+		}
+		data, err := m.stateSerializer.Marshal(stateChange.value)
+		if err != nil {
+			return err
+		}
+		values[stateChange.stateName] = data
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	key := actorKey(actorType, actorID)
+	for _, stateChange := range changes {
+		if stateChange == nil {
+			continue
+		}
+		switch stateChange.changeKind {
+		case Add, Update:
+			if m.states[key] == nil {
+				m.states[key] = make(map[string][]byte)
+			}
+			m.states[key][stateChange.stateName] = values[stateChange.stateName]
+		case Remove:
+			delete(m.states[key], stateChange.stateName)
+		}
+	}
+	if len(m.states[key]) == 0 {
+		delete(m.states, key)
+	}
+	return nil
+}
+
+func actorKey(actorType, actorID string) string {
+	return actorType + "/" + actorID
+}
@@ -0,0 +1,105 @@
+package state
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dapr/go-sdk/actor/state/constant"
+	"github.com/dapr/go-sdk/client"
+)
+
+func TestMemoryStateProvider(t *testing.T) {
+	provider := NewMemoryStateProvider()
+
+	exists, err := provider.Contains("testActorType", "testActorID", "count")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	var count int
+	assert.Error(t, provider.Load("testActorType", "testActorID", "count", &count))
+
+	err = provider.Apply("testActorType", "testActorID", []*ActorStateChange{
+		NewActorStateChange("count", 1, Add),
+		NewActorStateChange("name", "foo", Add),
+		nil,
+	})
+	assert.NoError(t, err)
+	exists, err = provider.Contains("testActorType", "testActorID", "count")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.NoError(t, provider.Load("testActorType", "testActorID", "count", &count))
+	assert.Equal(t, 1, count)
+
+	// states of other actors are isolated
+	exists, err = provider.Contains("testActorType", "otherActorID", "count")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	var name string
+	assert.NoError(t, provider.LoadBatch("testActorType", "testActorID", map[string]interface{}{
+		"count": &count,
+		"name":  &name,
+	}))
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "foo", name)
+	assert.Error(t, provider.LoadBatch("testActorType", "testActorID", map[string]interface{}{
+		"count":   &count,
+		"missing": &name,
+	}))
+
+	err = provider.Apply("testActorType", "testActorID", []*ActorStateChange{
+		NewActorStateChange("count", 2, Update),
+		NewActorStateChange("name", nil, Remove),
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, provider.Load("testActorType", "testActorID", "count", &count))
+	assert.Equal(t, 2, count)
+	exists, err = provider.Contains("testActorType", "testActorID", "name")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// nothing is applied if one of the values can't be encoded
+	err = provider.Apply("testActorType", "testActorID", []*ActorStateChange{
+		NewActorStateChange("count", 3, Update),
+		NewActorStateChange("bad", make(chan int), Add),
+	})
+	assert.Error(t, err)
+	assert.NoError(t, provider.Load("testActorType", "testActorID", "count", &count))
+	assert.Equal(t, 2, count)
+}
+
+func TestActorStateManagerWithMemoryStateProvider(t *testing.T) {
+	provider := NewMemoryStateProvider()
+	stateManager := NewActorStateManager("testActorType", "testActorID", provider)
+	assert.NoError(t, stateManager.Add("count", 1))
+	assert.NoError(t, stateManager.Save())
+
+	// a new activation of the actor reads the saved state
+	stateManager = NewActorStateManager("testActorType", "testActorID", provider)
+	exists, err := stateManager.Contains("count")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	var count int
+	assert.NoError(t, stateManager.Get("count", &count))
+	assert.Equal(t, 1, count)
+	assert.Error(t, stateManager.Add("count", 2))
+}
+
+func TestGetStateProviderFactory(t *testing.T) {
+	f, err := GetStateProviderFactory(constant.MemoryStateProviderName)
+	assert.NoError(t, err)
+	provider, err := f(nil)
+	assert.NoError(t, err)
+	assert.IsType(t, &MemoryStateProvider{}, provider)
+
+	f, err = GetStateProviderFactory(constant.DefaultStateProviderName)
+	assert.NoError(t, err)
+	_, err = f(func() (client.Client, error) {
+		return nil, errors.New("connection refused")
+	})
+	assert.Error(t, err)
+
+	_, err = GetStateProviderFactory("badStateProviderName")
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package state
 
 import (
 	"context"
+	"sort"
 
 	"github.com/pkg/errors"
 
@@ -10,6 +11,15 @@ import (
 	client "github.com/dapr/go-sdk/client"
 )
 
+const (
+	upsertOperation = "upsert"
+	deleteOperation = "delete"
+
+	// stateNamesKey is the key of the index of the state names of an actor, which is saved in the transaction of the
+	// states it lists, as Dapr can't list the keys of an actor. The state manager rejects it as a state name.
+	stateNamesKey = "_dapr_go_sdk_state_names"
+)
+
 type DaprStateAsyncProvider struct {
 	daprClient      client.Client
 	stateSerializer codec.Codec
@@ -37,7 +47,7 @@ func (d *DaprStateAsyncProvider) Load(actorType, actorID, stateName string, repl
 		return errors.Errorf("get actor state error = %s", err.Error())
 	}
 	if len(result.Data) == 0 {
-		return errors.Errorf("get actor state result empty, with actorType: %s, actorID: %s, stateName %s", actorType, actorID, stateName)
+		return errors.Wrapf(ErrStateNotFound, "get actor state result empty, with actorType: %s, actorID: %s, stateName %s", actorType, actorID, stateName)
 	}
 	if err := d.stateSerializer.Unmarshal(result.Data, reply); err != nil {
 		return errors.Errorf("unmarshal state data error = %s", err.Error())
@@ -45,40 +55,111 @@ func (d *DaprStateAsyncProvider) Load(actorType, actorID, stateName string, repl
 	return nil
 }
 
-func (d *DaprStateAsyncProvider) Apply(actorType, actorID string, changes []*ActorStateChange) error {
-	if len(changes) == 0 {
-		return nil
+func (d *DaprStateAsyncProvider) LoadBatch(actorType, actorID string, replies map[string]interface{}) error {
+	for stateName, reply := range replies {
+		if err := d.Load(actorType, actorID, stateName, reply); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	operations := make([]*client.ActorStateOperation, 0)
-	var value []byte
+// Apply saves @changes in one Dapr transaction, along with the index of the state names of the actor read by
+// ListStateNames if they change it. The index is read from Dapr first, so saving changes costs one more state read.
+func (d *DaprStateAsyncProvider) Apply(actorType, actorID string, changes []*ActorStateChange) error {
+	operations := make([]*client.ActorStateOperation, 0, len(changes))
 	for _, stateChange := range changes {
 		if stateChange == nil {
 			continue
 		}
 
-		daprOperationName := string(stateChange.changeKind)
-		if len(daprOperationName) == 0 {
-			continue
+		if stateChange.stateName == stateNamesKey {
+			return errors.Errorf("state name %s is reserved", stateNamesKey)
 		}
-
-		if stateChange.changeKind == Add {
+		operation := &client.ActorStateOperation{
+			Key: stateChange.stateName,
+		}
+		switch stateChange.changeKind {
+		case Add, Update:
 			data, err := d.stateSerializer.Marshal(stateChange.value)
 			if err != nil {
 				return err
 			}
-			value = data
+			operation.OperationType = upsertOperation
+			operation.Value = data
+		case Remove:
+			operation.OperationType = deleteOperation
+		default:
+			continue
 		}
-		operations = append(operations, &client.ActorStateOperation{
-			OperationType: daprOperationName,
-			Key:           stateChange.stateName,
-			Value:         value,
-		})
+		operations = append(operations, operation)
+	}
+	if len(operations) == 0 {
+		return nil
+	}
+	indexOperation, err := d.indexStateNames(actorType, actorID, operations)
+	if err != nil {
+		return err
+	}
+	if indexOperation != nil {
+		operations = append(operations, indexOperation)
 	}
 	return d.daprClient.SaveStateTransactionally(context.Background(), actorType, actorID, operations)
 }
 
-// TODO(@laurence) the daprClient may be nil.
+// ListStateNames returns the names of the states of the actor listed by the index of its state names. The index is
+// only kept by this provider, so the states saved before it's kept, or by other Dapr SDKs, are not listed until they
+// are saved again by this provider, and the states deleted by them are still listed.
+func (d *DaprStateAsyncProvider) ListStateNames(actorType, actorID string) ([]string, error) {
+	names := make([]string, 0)
+	if err := d.Load(actorType, actorID, stateNamesKey, &names); err != nil && !errors.Is(err, ErrStateNotFound) {
+		return nil, err
+	}
+	return names, nil
+}
+
+// indexStateNames returns the operation saving the index of the state names of the actor changed by @operations, it's
+// nil if the index doesn't change. It reads the index through Dapr, which costs each save of changes one more read.
+func (d *DaprStateAsyncProvider) indexStateNames(actorType, actorID string, operations []*client.ActorStateOperation) (*client.ActorStateOperation, error) {
+	names, err := d.ListStateNames(actorType, actorID)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		index[name] = struct{}{}
+	}
+	changed := false
+	for _, operation := range operations {
+		_, indexed := index[operation.Key]
+		switch {
+		case operation.OperationType == upsertOperation && !indexed:
+			index[operation.Key] = struct{}{}
+			changed = true
+		case operation.OperationType == deleteOperation && indexed:
+			delete(index, operation.Key)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil, nil
+	}
+	if len(index) == 0 {
+		return &client.ActorStateOperation{OperationType: deleteOperation, Key: stateNamesKey}, nil
+	}
+	names = make([]string, 0, len(index))
+	for name := range index {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	data, err := d.stateSerializer.Marshal(names)
+	if err != nil {
+		return nil, err
+	}
+	return &client.ActorStateOperation{OperationType: upsertOperation, Key: stateNamesKey, Value: data}, nil
+}
+
+// NewDaprStateAsyncProvider creates the state provider backed by the Dapr sidecar, which is the default one of actors.
 func NewDaprStateAsyncProvider(daprClient client.Client) *DaprStateAsyncProvider {
 	stateSerializer, _ := codec.GetActorCodec(constant.DefaultSerializerType)
 	return &DaprStateAsyncProvider{
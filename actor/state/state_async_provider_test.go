@@ -1,44 +1,172 @@
 package state
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/dapr/go-sdk/actor/codec"
 	"github.com/dapr/go-sdk/client"
 )
 
-func TestDaprStateAsyncProvider_Apply(t *testing.T) {
-	type fields struct {
-		daprClient      client.Client
-		stateSerializer codec.Codec
+// transactionClient records the operations saved by SaveStateTransactionally and the states read, and answers the
+// states they saved.
+type transactionClient struct {
+	client.Client
+	operations []*client.ActorStateOperation
+	states     map[string][]byte
+	reads      []string
+}
+
+func (c *transactionClient) SaveStateTransactionally(ctx context.Context, actorType, actorID string, operations []*client.ActorStateOperation) error {
+	c.operations = append(c.operations, operations...)
+	if c.states == nil {
+		c.states = make(map[string][]byte)
 	}
+	for _, operation := range operations {
+		key := actorType + "/" + actorID + "/" + operation.Key
+		if operation.OperationType == upsertOperation {
+			c.states[key] = operation.Value
+		} else {
+			delete(c.states, key)
+		}
+	}
+	return nil
+}
+
+func (c *transactionClient) GetActorState(ctx context.Context, in *client.GetActorStateRequest) (*client.GetActorStateResponse, error) {
+	c.reads = append(c.reads, in.KeyName)
+	return &client.GetActorStateResponse{Data: c.states[in.ActorType+"/"+in.ActorID+"/"+in.KeyName]}, nil
+}
+
+func TestDaprStateAsyncProvider_Apply(t *testing.T) {
 	type args struct {
 		actorType string
 		actorID   string
 		changes   []*ActorStateChange
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
+		name           string
+		args           args
+		wantOperations []*client.ActorStateOperation
+		wantErr        bool
 	}{
-		// TODO: Add test cases.
+		{
+			name: "no changes",
+			args: args{actorType: "testActorType", actorID: "testActorID"},
+		},
+		{
+			name: "unchanged states are skipped",
+			args: args{
+				actorType: "testActorType",
+				actorID:   "testActorID",
+				changes:   []*ActorStateChange{NewActorStateChange("a", "foo", None), nil},
+			},
+		},
+		{
+			name: "add, update and remove",
+			args: args{
+				actorType: "testActorType",
+				actorID:   "testActorID",
+				changes: []*ActorStateChange{
+					NewActorStateChange("a", "foo", Add),
+					NewActorStateChange("b", 1, Update),
+					NewActorStateChange("c", nil, Remove),
+				},
+			},
+			wantOperations: []*client.ActorStateOperation{
+				{OperationType: "upsert", Key: "a", Value: []byte(`"foo"`)},
+				{OperationType: "upsert", Key: "b", Value: []byte(`1`)},
+				{OperationType: "delete", Key: "c"},
+				{OperationType: "upsert", Key: stateNamesKey, Value: []byte(`["a","b"]`)},
+			},
+		},
+		{
+			name: "delete doesn't carry the value of the previous change",
+			args: args{
+				actorType: "testActorType",
+				actorID:   "testActorID",
+				changes: []*ActorStateChange{
+					NewActorStateChange("a", "foo", Update),
+					NewActorStateChange("b", "bar", Remove),
+				},
+			},
+			wantOperations: []*client.ActorStateOperation{
+				{OperationType: "upsert", Key: "a", Value: []byte(`"foo"`)},
+				{OperationType: "delete", Key: "b"},
+				{OperationType: "upsert", Key: stateNamesKey, Value: []byte(`["a"]`)},
+			},
+		},
+		{
+			name: "reserved state name",
+			args: args{
+				actorType: "testActorType",
+				actorID:   "testActorID",
+				changes:   []*ActorStateChange{NewActorStateChange(stateNamesKey, "foo", Add)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "value can't be encoded",
+			args: args{
+				actorType: "testActorType",
+				actorID:   "testActorID",
+				changes:   []*ActorStateChange{NewActorStateChange("a", make(chan int), Add)},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			d := &DaprStateAsyncProvider{
-				daprClient:      tt.fields.daprClient,
-				stateSerializer: tt.fields.stateSerializer,
-			}
+			daprClient := &transactionClient{}
+			d := NewDaprStateAsyncProvider(daprClient)
 			if err := d.Apply(tt.args.actorType, tt.args.actorID, tt.args.changes); (err != nil) != tt.wantErr {
 				t.Errorf("Apply() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if !reflect.DeepEqual(daprClient.operations, tt.wantOperations) {
+				t.Errorf("Apply() operations = %v, want %v", daprClient.operations, tt.wantOperations)
+			}
 		})
 	}
 }
 
+func TestDaprStateAsyncProvider_ListStateNames(t *testing.T) {
+	daprClient := &transactionClient{}
+	d := NewDaprStateAsyncProvider(daprClient)
+	names, err := d.ListStateNames("testActorType", "testActorID")
+	assert.NoError(t, err)
+	assert.Empty(t, names)
+
+	assert.NoError(t, d.Apply("testActorType", "testActorID", []*ActorStateChange{
+		NewActorStateChange("b", "bar", Add),
+		NewActorStateChange("a", "foo", Update),
+	}))
+	names, err = d.ListStateNames("testActorType", "testActorID")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, names)
+
+	// the index is saved only when names are added or removed, but it's read by every save of changes
+	daprClient.operations = nil
+	daprClient.reads = nil
+	assert.NoError(t, d.Apply("testActorType", "testActorID", []*ActorStateChange{NewActorStateChange("a", "changed", Update)}))
+	assert.Len(t, daprClient.operations, 1)
+	assert.Equal(t, []string{stateNamesKey}, daprClient.reads)
+	daprClient.reads = nil
+	assert.NoError(t, d.Apply("testActorType", "testActorID", []*ActorStateChange{NewActorStateChange("a", "changed", None)}))
+	assert.Empty(t, daprClient.reads)
+
+	assert.NoError(t, d.Apply("testActorType", "testActorID", []*ActorStateChange{
+		NewActorStateChange("a", nil, Remove),
+		NewActorStateChange("b", nil, Remove),
+	}))
+	names, err = d.ListStateNames("testActorType", "testActorID")
+	assert.NoError(t, err)
+	assert.Empty(t, names)
+	assert.Empty(t, daprClient.states)
+}
+
 func TestDaprStateAsyncProvider_Contains(t *testing.T) {
 	type fields struct {
 		daprClient      client.Client
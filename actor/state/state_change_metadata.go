@@ -3,15 +3,21 @@ package state
 type ChangeKind string
 
 const (
-	None   = ChangeKind("")
-	Add    = ChangeKind("upsert")
-	Update = ChangeKind("upsert")
-	Remove = ChangeKind("delete")
+	// None is a state loaded from the provider and not changed since.
+	None = ChangeKind("")
+	// Add is a state known not to exist in the provider, it is upserted on save.
+	Add = ChangeKind("add")
+	// Update is a state which may exist in the provider, it is upserted on save.
+	Update = ChangeKind("update")
+	// Remove is a state which may exist in the provider, it is deleted on save.
+	Remove = ChangeKind("remove")
 )
 
 type ChangeMetadata struct {
 	Kind  ChangeKind
 	Value interface{}
+	// lastAccess is the sequence of the last access to the state in cache
+	lastAccess uint64
 }
 
 func NewChangeMetadata(kind ChangeKind, value interface{}) *ChangeMetadata {
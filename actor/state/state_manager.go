@@ -1,24 +1,37 @@
 package state
 
 import (
+	"encoding/json"
 	"reflect"
+	"sort"
 	"sync"
+	"sync/atomic"
 
 	"github.com/pkg/errors"
 
 	"github.com/dapr/go-sdk/actor"
+	"github.com/dapr/go-sdk/actor/config"
 )
 
 type ActorStateManager struct {
 	ActorTypeName      string
 	ActorID            string
 	stateChangeTracker sync.Map // map[string]*ChangeMetadata
-	stateAsyncProvider *DaprStateAsyncProvider
+	stateAsyncProvider StateProvider
+	cachePolicy        config.StateCachePolicy
+	// accessSeq orders the accesses of states, to evict the least recently used ones from cache
+	accessSeq uint64
+	// snapshots is set by EnableSnapshots, the cache is encoded for Dump at the end of each turn only if it's set
+	snapshots int32
+	// snapshot is the cache as dumped by Dump, which is encoded at the end of each turn, so that it's never read
+	// while a turn changes the cached values
+	snapshot     []CachedState
+	snapshotLock sync.RWMutex
 }
 
 func (a *ActorStateManager) Add(stateName string, value interface{}) error {
-	if stateName == "" {
-		return errors.Errorf("state's name can't be empty")
+	if err := checkStateName(stateName); err != nil {
+		return err
 	}
 	exists, err := a.stateAsyncProvider.Contains(a.ActorTypeName, a.ActorID, stateName)
 	if err != nil {
@@ -28,7 +41,7 @@ func (a *ActorStateManager) Add(stateName string, value interface{}) error {
 	if val, ok := a.stateChangeTracker.Load(stateName); ok {
 		metadata := val.(*ChangeMetadata)
 		if metadata.Kind == Remove {
-			a.stateChangeTracker.Store(stateName, &ChangeMetadata{
+			a.track(stateName, &ChangeMetadata{
 				Kind:  Update,
 				Value: value,
 			})
@@ -39,7 +52,7 @@ func (a *ActorStateManager) Add(stateName string, value interface{}) error {
 	if exists {
 		return errors.Errorf("Duplicate state: %s", stateName)
 	}
-	a.stateChangeTracker.Store(stateName, &ChangeMetadata{
+	a.track(stateName, &ChangeMetadata{
 		Kind:  Add,
 		Value: value,
 	})
@@ -47,56 +60,128 @@ func (a *ActorStateManager) Add(stateName string, value interface{}) error {
 }
 
 func (a *ActorStateManager) Get(stateName string, reply interface{}) error {
-	if stateName == "" {
-		return errors.Errorf("state's name can't be empty")
+	found, err := a.TryGet(stateName, reply)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.Wrapf(ErrStateNotFound, "state %s", stateName)
+	}
+	return nil
+}
+
+// TryGet gets the state @stateName into @reply, found is false if the state doesn't exist or is marked for remove.
+// Unlike calling Contains and then Get, the state provider is called once at most.
+func (a *ActorStateManager) TryGet(stateName string, reply interface{}) (bool, error) {
+	if err := checkStateName(stateName); err != nil {
+		return false, err
 	}
 
 	if val, ok := a.stateChangeTracker.Load(stateName); ok {
 		metadata := val.(*ChangeMetadata)
 		if metadata.Kind == Remove {
-			return errors.Errorf("state is marked for remove: %s", stateName)
+			return false, nil
 		}
-		replyVal := reflect.ValueOf(reply).Elem()
-		metadataValue := reflect.ValueOf(metadata.Value)
-		if metadataValue.Kind() == reflect.Ptr {
-			replyVal.Set(metadataValue.Elem())
-		} else {
-			replyVal.Set(metadataValue)
+		metadata.lastAccess = atomic.AddUint64(&a.accessSeq, 1)
+		if err := copyValue(reply, metadata.Value); err != nil {
+			return false, err
 		}
-
-		return nil
+		return true, nil
 	}
 
-	err := a.stateAsyncProvider.Load(a.ActorTypeName, a.ActorID, stateName, reply)
-	a.stateChangeTracker.Store(stateName, &ChangeMetadata{
+	if err := a.stateAsyncProvider.Load(a.ActorTypeName, a.ActorID, stateName, reply); err != nil {
+		if errors.Is(err, ErrStateNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	a.track(stateName, &ChangeMetadata{
 		Kind:  None,
 		Value: reply,
 	})
-	return err
+	return true, nil
 }
 
-func (a *ActorStateManager) Set(stateName string, value interface{}) error {
-	if stateName == "" {
-		return errors.Errorf("state's name can't be empty")
+// GetOrDefault gets the state @stateName into @reply, @defaultValue is copied into @reply if the state doesn't exist.
+func (a *ActorStateManager) GetOrDefault(stateName string, reply interface{}, defaultValue interface{}) error {
+	found, err := a.TryGet(stateName, reply)
+	if err != nil || found {
+		return err
 	}
-	if val, ok := a.stateChangeTracker.Load(stateName); ok {
-		metadata := val.(*ChangeMetadata)
-		if metadata.Kind == None || metadata.Kind == Remove {
-			metadata.Kind = Update
+	return copyValue(reply, defaultValue)
+}
+
+// AddOrUpdate sets the state @stateName as @addValue if it doesn't exist, or as the value returned by @update
+// otherwise. @update is called with the current value, which has the same type as @addValue.
+func (a *ActorStateManager) AddOrUpdate(stateName string, addValue interface{}, update func(oldValue interface{}) (interface{}, error)) error {
+	if addValue == nil {
+		return errors.Errorf("state's add value can't be nil")
+	}
+	oldValue := reflect.New(reflect.TypeOf(addValue))
+	found, err := a.TryGet(stateName, oldValue.Interface())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return a.Set(stateName, addValue)
+	}
+	newValue, err := update(oldValue.Elem().Interface())
+	if err != nil {
+		return err
+	}
+	return a.Set(stateName, newValue)
+}
+
+// GetStateNames returns the sorted names of the states of the actor, including the changes not saved yet.
+// It fails if the state provider doesn't implement StateNamesLister, which the built-in providers do.
+func (a *ActorStateManager) GetStateNames() ([]string, error) {
+	lister, ok := a.stateAsyncProvider.(StateNamesLister)
+	if !ok {
+		return nil, errors.Errorf("state provider %T can't list state names", a.stateAsyncProvider)
+	}
+	names, err := lister.ListStateNames(a.ActorTypeName, a.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	nameSet := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		nameSet[name] = struct{}{}
+	}
+	a.stateChangeTracker.Range(func(key, value interface{}) bool {
+		if value.(*ChangeMetadata).Kind == Remove {
+			delete(nameSet, key.(string))
+		} else {
+			nameSet[key.(string)] = struct{}{}
 		}
-		a.stateChangeTracker.Store(stateName, NewChangeMetadata(metadata.Kind, value))
-		return nil
+		return true
+	})
+	names = make([]string, 0, len(nameSet))
+	for name := range nameSet {
+		names = append(names, name)
 	}
-	a.stateChangeTracker.Store(stateName, &ChangeMetadata{
-		Kind:  Add,
+	sort.Strings(names)
+	return names, nil
+}
+
+func (a *ActorStateManager) Set(stateName string, value interface{}) error {
+	if err := checkStateName(stateName); err != nil {
+		return err
+	}
+	// a state not tracked may exist in the provider, so it's an update rather than an add.
+	kind := Update
+	if val, ok := a.stateChangeTracker.Load(stateName); ok && val.(*ChangeMetadata).Kind == Add {
+		kind = Add
+	}
+	a.track(stateName, &ChangeMetadata{
+		Kind:  kind,
 		Value: value,
 	})
 	return nil
 }
 
 func (a *ActorStateManager) Remove(stateName string) error {
-	if stateName == "" {
-		return errors.Errorf("state's name can't be empty")
+	if err := checkStateName(stateName); err != nil {
+		return err
 	}
 	if val, ok := a.stateChangeTracker.Load(stateName); ok {
 		metadata := val.(*ChangeMetadata)
@@ -108,14 +193,18 @@ func (a *ActorStateManager) Remove(stateName string) error {
 			return nil
 		}
 
-		a.stateChangeTracker.Store(stateName, &ChangeMetadata{
+		a.track(stateName, &ChangeMetadata{
 			Kind:  Remove,
 			Value: nil,
 		})
 		return nil
 	}
-	if exist, err := a.stateAsyncProvider.Contains(a.ActorTypeName, a.ActorID, stateName); err != nil && exist {
-		a.stateChangeTracker.Store(stateName, &ChangeMetadata{
+	exist, err := a.stateAsyncProvider.Contains(a.ActorTypeName, a.ActorID, stateName)
+	if err != nil {
+		return err
+	}
+	if exist {
+		a.track(stateName, &ChangeMetadata{
 			Kind:  Remove,
 			Value: nil,
 		})
@@ -124,8 +213,8 @@ func (a *ActorStateManager) Remove(stateName string) error {
 }
 
 func (a *ActorStateManager) Contains(stateName string) (bool, error) {
-	if stateName == "" {
-		return false, errors.Errorf("state's name can't be empty")
+	if err := checkStateName(stateName); err != nil {
+		return false, err
 	}
 	if val, ok := a.stateChangeTracker.Load(stateName); ok {
 		metadata := val.(*ChangeMetadata)
@@ -148,28 +237,167 @@ func (a *ActorStateManager) Save() error {
 	if err := a.stateAsyncProvider.Apply(a.ActorTypeName, a.ActorID, changes); err != nil {
 		return err
 	}
+	a.takeSnapshot(false)
 	a.Flush()
 	return nil
 }
 
+// Flush marks the saved states as unchanged, and evicts states from cache as the cache policy requires.
 func (a *ActorStateManager) Flush() {
+	cached := make([]*cachedState, 0)
 	a.stateChangeTracker.Range(func(key, value interface{}) bool {
 		stateName := key.(string)
 		metadata := value.(*ChangeMetadata)
-		if metadata.Kind == Remove {
+		if metadata.Kind == Remove || a.cachePolicy.EvictAfterSave {
 			a.stateChangeTracker.Delete(stateName)
 			return true
 		}
-		metadata = NewChangeMetadata(None, metadata.Value)
-		a.stateChangeTracker.Store(stateName, metadata)
+		unchanged := NewChangeMetadata(None, metadata.Value)
+		unchanged.lastAccess = metadata.lastAccess
+		a.stateChangeTracker.Store(stateName, unchanged)
+		cached = append(cached, &cachedState{name: stateName, lastAccess: metadata.lastAccess})
+		return true
+	})
+	if a.cachePolicy.MaxEntries > 0 && len(cached) > a.cachePolicy.MaxEntries {
+		sort.Slice(cached, func(i, j int) bool {
+			return cached[i].lastAccess < cached[j].lastAccess
+		})
+		for _, state := range cached[:len(cached)-a.cachePolicy.MaxEntries] {
+			a.stateChangeTracker.Delete(state.name)
+		}
+	}
+}
+
+// Discard drops the changes not saved yet, the unchanged states are kept in cache. It's called when the turn of
+// the actor fails, so that its changes are not saved by the next turn.
+func (a *ActorStateManager) Discard() {
+	a.takeSnapshot(true)
+	a.stateChangeTracker.Range(func(key, value interface{}) bool {
+		if value.(*ChangeMetadata).Kind != None {
+			a.stateChangeTracker.Delete(key)
+		}
+		return true
+	})
+}
+
+// CachedState is a state in the cache of an actor, as dumped by Dump for debugging.
+type CachedState struct {
+	Name string `json:"name"`
+	// Kind is the change of the state made by the last turn, it's empty for states loaded and unchanged since.
+	Kind ChangeKind `json:"kind,omitempty"`
+	// Dirty is set if the change was not saved, as the last turn or its save failed.
+	Dirty bool `json:"dirty"`
+	// Value is the JSON encoded value of the state, Error is set instead if it can't be encoded.
+	Value json.RawMessage `json:"value,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+// EnableSnapshots makes the state manager encode its cache for Dump at the end of each turn, which it doesn't by
+// default so that turns don't pay for debugging.
+func (a *ActorStateManager) EnableSnapshots() {
+	atomic.StoreInt32(&a.snapshots, 1)
+}
+
+// Dump returns the states in cache sorted by name with the changes made by the last turn of the actor, as they were
+// at the end of that turn. It's safe to call while a turn runs, and it's empty unless EnableSnapshots is called
+// before the turn.
+func (a *ActorStateManager) Dump() []CachedState {
+	a.snapshotLock.RLock()
+	defer a.snapshotLock.RUnlock()
+	if a.snapshot == nil {
+		return make([]CachedState, 0)
+	}
+	return a.snapshot
+}
+
+// takeSnapshot encodes the states in cache for Dump if snapshots are enabled, it's called by the turn of the actor
+// which ends, before its changes are flushed, or discarded if @dirty is set.
+func (a *ActorStateManager) takeSnapshot(dirty bool) {
+	if atomic.LoadInt32(&a.snapshots) == 0 {
+		return
+	}
+	states := make([]CachedState, 0)
+	a.stateChangeTracker.Range(func(key, value interface{}) bool {
+		metadata := value.(*ChangeMetadata)
+		cached := CachedState{
+			Name:  key.(string),
+			Kind:  metadata.Kind,
+			Dirty: dirty && metadata.Kind != None,
+		}
+		if metadata.Kind != Remove {
+			data, err := json.Marshal(metadata.Value)
+			if err != nil {
+				cached.Error = err.Error()
+			} else {
+				cached.Value = data
+			}
+		}
+		states = append(states, cached)
 		return true
 	})
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Name < states[j].Name
+	})
+	a.snapshotLock.Lock()
+	defer a.snapshotLock.Unlock()
+	a.snapshot = states
+}
+
+// track stores @metadata of the state @stateName in cache as the most recently used one.
+func (a *ActorStateManager) track(stateName string, metadata *ChangeMetadata) {
+	metadata.lastAccess = atomic.AddUint64(&a.accessSeq, 1)
+	a.stateChangeTracker.Store(stateName, metadata)
+}
+
+type cachedState struct {
+	name       string
+	lastAccess uint64
 }
 
-func NewActorStateManager(actorTypeName string, actorID string, provider *DaprStateAsyncProvider) actor.StateManager {
+func NewActorStateManager(actorTypeName string, actorID string, provider StateProvider) actor.StateManager {
+	return NewActorStateManagerWithCachePolicy(actorTypeName, actorID, provider, config.StateCachePolicy{})
+}
+
+// NewActorStateManagerWithCachePolicy creates the state manager of an actor, which keeps states in cache as
+// @cachePolicy requires.
+func NewActorStateManagerWithCachePolicy(actorTypeName string, actorID string, provider StateProvider, cachePolicy config.StateCachePolicy) actor.StateManager {
 	return &ActorStateManager{
 		stateAsyncProvider: provider,
 		ActorTypeName:      actorTypeName,
 		ActorID:            actorID,
+		cachePolicy:        cachePolicy,
 	}
 }
+
+// checkStateName checks that @stateName is not empty, nor the name reserved for the index of state names.
+func checkStateName(stateName string) error {
+	if stateName == "" {
+		return errors.Errorf("state's name can't be empty")
+	}
+	if stateName == stateNamesKey {
+		return errors.Errorf("state name %s is reserved", stateNamesKey)
+	}
+	return nil
+}
+
+// copyValue copies @value, or the value it points to, into the value @reply points to.
+func copyValue(reply interface{}, value interface{}) error {
+	replyVal := reflect.ValueOf(reply)
+	if replyVal.Kind() != reflect.Ptr || replyVal.IsNil() {
+		return errors.Errorf("reply must be a non-nil pointer, got %T", reply)
+	}
+	replyVal = replyVal.Elem()
+	val := reflect.ValueOf(value)
+	if val.Kind() == reflect.Ptr && !val.Type().AssignableTo(replyVal.Type()) {
+		val = val.Elem()
+	}
+	if !val.IsValid() {
+		replyVal.Set(reflect.Zero(replyVal.Type()))
+		return nil
+	}
+	if !val.Type().AssignableTo(replyVal.Type()) {
+		return errors.Errorf("state value of type %T can't be assigned to %T", value, reply)
+	}
+	replyVal.Set(val)
+	return nil
+}
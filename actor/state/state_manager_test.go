@@ -0,0 +1,431 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dapr/go-sdk/actor/config"
+)
+
+// recordStateProvider records the changes applied to the in-memory provider it wraps.
+type recordStateProvider struct {
+	*MemoryStateProvider
+	changes map[string]*ActorStateChange
+}
+
+func (r *recordStateProvider) Apply(actorType, actorID string, changes []*ActorStateChange) error {
+	for _, change := range changes {
+		r.changes[change.stateName] = change
+	}
+	return r.MemoryStateProvider.Apply(actorType, actorID, changes)
+}
+
+func TestActorStateManager_Save(t *testing.T) {
+	tests := []struct {
+		name string
+		// stored are the states saved before the turn
+		stored      map[string]interface{}
+		ops         func(a *ActorStateManager) error
+		wantChanges map[string]ChangeKind
+		wantStored  map[string]interface{}
+		wantErr     bool
+	}{
+		{
+			name:        "add",
+			ops:         func(a *ActorStateManager) error { return a.Add("a", "foo") },
+			wantChanges: map[string]ChangeKind{"a": Add},
+			wantStored:  map[string]interface{}{"a": "foo"},
+		},
+		{
+			name:    "add existing",
+			stored:  map[string]interface{}{"a": "foo"},
+			ops:     func(a *ActorStateManager) error { return a.Add("a", "bar") },
+			wantErr: true,
+		},
+		{
+			name:        "set not tracked",
+			stored:      map[string]interface{}{"a": "foo"},
+			ops:         func(a *ActorStateManager) error { return a.Set("a", "bar") },
+			wantChanges: map[string]ChangeKind{"a": Update},
+			wantStored:  map[string]interface{}{"a": "bar"},
+		},
+		{
+			name: "set added",
+			ops: func(a *ActorStateManager) error {
+				if err := a.Add("a", "foo"); err != nil {
+					return err
+				}
+				return a.Set("a", "bar")
+			},
+			wantChanges: map[string]ChangeKind{"a": Add},
+			wantStored:  map[string]interface{}{"a": "bar"},
+		},
+		{
+			name:   "get unchanged",
+			stored: map[string]interface{}{"a": "foo"},
+			ops: func(a *ActorStateManager) error {
+				var value string
+				return a.Get("a", &value)
+			},
+			wantChanges: map[string]ChangeKind{"a": None},
+			wantStored:  map[string]interface{}{"a": "foo"},
+		},
+		{
+			name:   "get then set",
+			stored: map[string]interface{}{"a": "foo"},
+			ops: func(a *ActorStateManager) error {
+				var value string
+				if err := a.Get("a", &value); err != nil {
+					return err
+				}
+				return a.Set("a", value+"bar")
+			},
+			wantChanges: map[string]ChangeKind{"a": Update},
+			wantStored:  map[string]interface{}{"a": "foobar"},
+		},
+		{
+			name:        "remove not tracked",
+			stored:      map[string]interface{}{"a": "foo"},
+			ops:         func(a *ActorStateManager) error { return a.Remove("a") },
+			wantChanges: map[string]ChangeKind{"a": Remove},
+			wantStored:  map[string]interface{}{},
+		},
+		{
+			name:        "remove not existing",
+			ops:         func(a *ActorStateManager) error { return a.Remove("a") },
+			wantChanges: map[string]ChangeKind{},
+			wantStored:  map[string]interface{}{},
+		},
+		{
+			name: "remove added",
+			ops: func(a *ActorStateManager) error {
+				if err := a.Add("a", "foo"); err != nil {
+					return err
+				}
+				return a.Remove("a")
+			},
+			wantChanges: map[string]ChangeKind{},
+			wantStored:  map[string]interface{}{},
+		},
+		{
+			name:   "remove updated",
+			stored: map[string]interface{}{"a": "foo"},
+			ops: func(a *ActorStateManager) error {
+				if err := a.Set("a", "bar"); err != nil {
+					return err
+				}
+				return a.Remove("a")
+			},
+			wantChanges: map[string]ChangeKind{"a": Remove},
+			wantStored:  map[string]interface{}{},
+		},
+		{
+			name:   "remove loaded",
+			stored: map[string]interface{}{"a": "foo"},
+			ops: func(a *ActorStateManager) error {
+				var value string
+				if err := a.Get("a", &value); err != nil {
+					return err
+				}
+				return a.Remove("a")
+			},
+			wantChanges: map[string]ChangeKind{"a": Remove},
+			wantStored:  map[string]interface{}{},
+		},
+		{
+			name:   "add removed",
+			stored: map[string]interface{}{"a": "foo"},
+			ops: func(a *ActorStateManager) error {
+				if err := a.Remove("a"); err != nil {
+					return err
+				}
+				return a.Add("a", "bar")
+			},
+			wantChanges: map[string]ChangeKind{"a": Update},
+			wantStored:  map[string]interface{}{"a": "bar"},
+		},
+		{
+			name:   "set removed",
+			stored: map[string]interface{}{"a": "foo"},
+			ops: func(a *ActorStateManager) error {
+				if err := a.Remove("a"); err != nil {
+					return err
+				}
+				return a.Set("a", "bar")
+			},
+			wantChanges: map[string]ChangeKind{"a": Update},
+			wantStored:  map[string]interface{}{"a": "bar"},
+		},
+		{
+			name: "get not existing",
+			ops: func(a *ActorStateManager) error {
+				var value string
+				if err := a.Get("a", &value); err == nil {
+					return a.Set("b", "unexpected")
+				}
+				exists, err := a.Contains("a")
+				if err != nil || exists {
+					return a.Set("b", "unexpected")
+				}
+				return nil
+			},
+			wantChanges: map[string]ChangeKind{},
+			wantStored:  map[string]interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &recordStateProvider{
+				MemoryStateProvider: NewMemoryStateProvider(),
+			}
+			for name, value := range tt.stored {
+				assert.NoError(t, provider.MemoryStateProvider.Apply("testActorType", "testActorID", []*ActorStateChange{
+					NewActorStateChange(name, value, Add),
+				}))
+			}
+			provider.changes = make(map[string]*ActorStateChange)
+			a := NewActorStateManager("testActorType", "testActorID", provider).(*ActorStateManager)
+
+			err := tt.ops(a)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NoError(t, a.Save())
+
+			changes := make(map[string]ChangeKind)
+			for name, change := range provider.changes {
+				changes[name] = change.changeKind
+			}
+			assert.Equal(t, tt.wantChanges, changes)
+			stored := make(map[string]interface{})
+			for name := range provider.states[actorKey("testActorType", "testActorID")] {
+				var value interface{}
+				assert.NoError(t, provider.Load("testActorType", "testActorID", name, &value))
+				stored[name] = value
+			}
+			assert.Equal(t, tt.wantStored, stored)
+
+			// all changes are flushed after save
+			provider.changes = make(map[string]*ActorStateChange)
+			assert.NoError(t, a.Save())
+			for name, change := range provider.changes {
+				assert.Equal(t, None, change.changeKind, name)
+			}
+		})
+	}
+}
+
+func TestActorStateManager_Accessors(t *testing.T) {
+	provider := NewMemoryStateProvider()
+	assert.NoError(t, provider.Apply("testActorType", "testActorID", []*ActorStateChange{
+		NewActorStateChange("count", 1, Add),
+		NewActorStateChange("name", "foo", Add),
+	}))
+	a := NewActorStateManager("testActorType", "testActorID", provider)
+
+	t.Run("try get", func(t *testing.T) {
+		var count int
+		found, err := a.TryGet("count", &count)
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, 1, count)
+
+		found, err = a.TryGet("missing", &count)
+		assert.NoError(t, err)
+		assert.False(t, found)
+
+		var name int
+		_, err = a.TryGet("name", &name)
+		assert.Error(t, err)
+		_, err = a.TryGet("", &count)
+		assert.Error(t, err)
+	})
+
+	t.Run("reserved name", func(t *testing.T) {
+		var names []string
+		_, err := a.TryGet(stateNamesKey, &names)
+		assert.Error(t, err)
+		assert.Error(t, a.Set(stateNamesKey, names))
+		assert.Error(t, a.Add(stateNamesKey, names))
+		assert.Error(t, a.Remove(stateNamesKey))
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		var count int
+		err := a.Get("missing", &count)
+		assert.True(t, errors.Is(err, ErrStateNotFound))
+	})
+
+	t.Run("get or default", func(t *testing.T) {
+		var count int
+		assert.NoError(t, a.GetOrDefault("count", &count, 10))
+		assert.Equal(t, 1, count)
+		assert.NoError(t, a.GetOrDefault("missing", &count, 10))
+		assert.Equal(t, 10, count)
+		assert.Error(t, a.GetOrDefault("missing", &count, "10"))
+	})
+
+	t.Run("add or update", func(t *testing.T) {
+		increment := func(oldValue interface{}) (interface{}, error) {
+			return oldValue.(int) + 1, nil
+		}
+		assert.NoError(t, a.AddOrUpdate("count", 0, increment))
+		assert.NoError(t, a.AddOrUpdate("total", 0, increment))
+		assert.NoError(t, a.AddOrUpdate("total", 0, increment))
+		assert.Error(t, a.AddOrUpdate("total", nil, increment))
+		assert.Error(t, a.AddOrUpdate("total", 0, func(interface{}) (interface{}, error) {
+			return nil, errors.New("update failed")
+		}))
+
+		var count, total int
+		assert.NoError(t, a.Get("count", &count))
+		assert.Equal(t, 2, count)
+		assert.NoError(t, a.Get("total", &total))
+		assert.Equal(t, 1, total)
+	})
+
+	t.Run("get state names", func(t *testing.T) {
+		assert.NoError(t, a.Remove("name"))
+		names, err := a.GetStateNames()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"count", "total"}, names)
+
+		assert.NoError(t, a.Save())
+		names, err = a.GetStateNames()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"count", "total"}, names)
+
+		// the Dapr state provider lists the names it indexed
+		d := NewActorStateManager("testActorType", "testActorID", NewDaprStateAsyncProvider(&transactionClient{}))
+		assert.NoError(t, d.Set("b", "bar"))
+		assert.NoError(t, d.Save())
+		assert.NoError(t, d.Set("a", "foo"))
+		names, err = d.GetStateNames()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, names)
+	})
+}
+
+func TestActorStateManager_Discard(t *testing.T) {
+	provider := NewMemoryStateProvider()
+	assert.NoError(t, provider.Apply("testActorType", "testActorID", []*ActorStateChange{
+		NewActorStateChange("a", "foo", Add),
+		NewActorStateChange("b", "bar", Add),
+	}))
+	a := NewActorStateManager("testActorType", "testActorID", provider)
+
+	var value string
+	assert.NoError(t, a.Get("a", &value))
+	assert.NoError(t, a.Set("b", "changed"))
+	assert.NoError(t, a.Set("c", "new"))
+	a.Discard()
+	assert.NoError(t, a.Save())
+
+	assert.NoError(t, provider.Load("testActorType", "testActorID", "b", &value))
+	assert.Equal(t, "bar", value)
+	exists, err := provider.Contains("testActorType", "testActorID", "c")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// the unchanged state is kept in cache
+	_, ok := a.(*ActorStateManager).stateChangeTracker.Load("a")
+	assert.True(t, ok)
+}
+
+func TestActorStateManager_Dump(t *testing.T) {
+	provider := NewMemoryStateProvider()
+	assert.NoError(t, provider.Apply("testActorType", "testActorID", []*ActorStateChange{
+		NewActorStateChange("a", "foo", Add),
+		NewActorStateChange("b", "bar", Add),
+	}))
+	a := NewActorStateManager("testActorType", "testActorID", provider).(*ActorStateManager)
+
+	// nothing is dumped unless snapshots are enabled
+	assert.NoError(t, a.Set("a", "foo"))
+	assert.NoError(t, a.Save())
+	assert.Empty(t, a.Dump())
+	a.EnableSnapshots()
+
+	// the changes of a turn are dumped once the turn ends
+	var value string
+	assert.NoError(t, a.Get("a", &value))
+	assert.NoError(t, a.Remove("b"))
+	assert.NoError(t, a.Set("c", 3))
+	assert.Empty(t, a.Dump())
+	assert.NoError(t, a.Save())
+	states := []CachedState{
+		{Name: "a", Value: json.RawMessage(`"foo"`)},
+		{Name: "b", Kind: Remove},
+		{Name: "c", Kind: Update, Value: json.RawMessage(`3`)},
+	}
+	assert.Equal(t, states, a.Dump())
+
+	// the dump is not changed by the next turn until it ends, so that the values are not read while they change
+	assert.NoError(t, a.Set("d", 4))
+	assert.Equal(t, states, a.Dump())
+
+	// the changes of a failed turn are dumped as dirty
+	a.Discard()
+	assert.Equal(t, []CachedState{
+		{Name: "a", Value: json.RawMessage(`"foo"`)},
+		{Name: "c", Value: json.RawMessage(`3`)},
+		{Name: "d", Kind: Update, Dirty: true, Value: json.RawMessage(`4`)},
+	}, a.Dump())
+}
+
+func TestActorStateManager_CachePolicy(t *testing.T) {
+	cachedNames := func(a *ActorStateManager) []string {
+		names := make([]string, 0)
+		a.stateChangeTracker.Range(func(key, value interface{}) bool {
+			names = append(names, key.(string))
+			return true
+		})
+		sort.Strings(names)
+		return names
+	}
+	tests := []struct {
+		name        string
+		cachePolicy config.StateCachePolicy
+		wantCached  []string
+	}{
+		{
+			name:       "keep",
+			wantCached: []string{"a", "b", "c"},
+		},
+		{
+			name:        "evict after save",
+			cachePolicy: config.StateCachePolicy{EvictAfterSave: true},
+			wantCached:  []string{},
+		},
+		{
+			name:        "max entries",
+			cachePolicy: config.StateCachePolicy{MaxEntries: 2},
+			wantCached:  []string{"a", "c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewMemoryStateProvider()
+			a := NewActorStateManagerWithCachePolicy("testActorType", "testActorID", provider, tt.cachePolicy).(*ActorStateManager)
+			assert.NoError(t, a.Set("a", "foo"))
+			assert.NoError(t, a.Set("b", "bar"))
+			assert.NoError(t, a.Set("c", "baz"))
+			// a is used more recently than b
+			var value string
+			assert.NoError(t, a.Get("a", &value))
+			assert.NoError(t, a.Save())
+			assert.Equal(t, tt.wantCached, cachedNames(a))
+
+			// evicted states are loaded again
+			for _, name := range []string{"a", "b", "c"} {
+				assert.NoError(t, a.Get(name, &value))
+			}
+		})
+	}
+}
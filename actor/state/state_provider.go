@@ -0,0 +1,75 @@
+package state
+
+import (
+	"sync"
+
+	perrors "github.com/pkg/errors"
+
+	"github.com/dapr/go-sdk/actor/state/constant"
+	"github.com/dapr/go-sdk/client"
+)
+
+func init() {
+	SetStateProviderFactory(constant.DefaultStateProviderName, func(getClient ClientGetter) (StateProvider, error) {
+		daprClient, err := getClient()
+		if err != nil {
+			return nil, err
+		}
+		return NewDaprStateAsyncProvider(daprClient), nil
+	})
+	SetStateProviderFactory(constant.MemoryStateProviderName, func(ClientGetter) (StateProvider, error) {
+		return NewMemoryStateProvider(), nil
+	})
+}
+
+// StateProvider is the storage of actor states, ActorStateManager reads states from it and applies the tracked
+// changes to it when the state is saved.
+type StateProvider interface {
+	// Contains checks if the state named @stateName exists.
+	Contains(actorType, actorID, stateName string) (bool, error)
+	// Load decodes the state named @stateName into @reply, an error is returned if the state doesn't exist.
+	Load(actorType, actorID, stateName string, reply interface{}) error
+	// LoadBatch decodes the states named by the keys of @replies into the values, an error is returned if one of
+	// them doesn't exist.
+	LoadBatch(actorType, actorID string, replies map[string]interface{}) error
+	// Apply saves @changes of the actor transactionally.
+	Apply(actorType, actorID string, changes []*ActorStateChange) error
+}
+
+// StateNamesLister is implemented by state providers which can list the names of the states of an actor.
+type StateNamesLister interface {
+	ListStateNames(actorType, actorID string) ([]string, error)
+}
+
+// ErrStateNotFound is returned, wrapped, by state providers loading a state which doesn't exist.
+var ErrStateNotFound = perrors.New("actor state not found")
+
+// ClientGetter returns the Dapr client of the actor type, which is only called by providers backed by Dapr.
+type ClientGetter func() (client.Client, error)
+
+// StateProviderFactory creates the state provider shared by the actors of one actor type.
+type StateProviderFactory func(getClient ClientGetter) (StateProvider, error)
+
+var (
+	stateProviderFactoryMap  = make(map[string]StateProviderFactory)
+	stateProviderFactoryLock sync.RWMutex
+)
+
+// SetStateProviderFactory registers the state provider factory @f as @name, to be chosen with
+// config.WithStateProviderName.
+func SetStateProviderFactory(name string, f StateProviderFactory) {
+	stateProviderFactoryLock.Lock()
+	defer stateProviderFactoryLock.Unlock()
+	stateProviderFactoryMap[name] = f
+}
+
+// GetStateProviderFactory gets the state provider factory registered as @name.
+func GetStateProviderFactory(name string) (StateProviderFactory, error) {
+	stateProviderFactoryLock.RLock()
+	defer stateProviderFactoryLock.RUnlock()
+	f, ok := stateProviderFactoryMap[name]
+	if !ok {
+		return nil, perrors.Errorf("no actor state provider named %s", name)
+	}
+	return f, nil
+}
@@ -1,10 +1,12 @@
 package client
 
 import (
+	"container/list"
 	"context"
-	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
+	"sync"
 
 	anypb "github.com/golang/protobuf/ptypes/any"
 	"github.com/pkg/errors"
@@ -15,6 +17,11 @@ import (
 	"github.com/dapr/go-sdk/actor/config"
 )
 
+var typeOfError = reflect.TypeOf((*error)(nil)).Elem()
+
+// defaultActorProxyCacheSize is the maximum number of proxies cached by NewActorProxy unless set otherwise.
+const defaultActorProxyCacheSize = 1024
+
 type InvokeActorRequest struct {
 	ActorType string
 	ActorID   string
@@ -73,6 +80,7 @@ type ClientStub struct {
 	Post          func(context.Context, string) error
 	StartTimer    func(context.Context, *TimerRequest) error
 	StopTimer     func(context.Context, *TimerRequest) error
+	Add           func(context.Context, int, int) (int, error)
 	...
 }
 
@@ -84,17 +92,181 @@ func (a *ClientStub) Type() string {
 // ID defined actor ID to be invoked
 func (a *ClientStub) ID() string {
 	return "ActorImplID123456"
-}.
+}
+
+Methods take zero to N arguments after the optional context. A single argument is sent as it is, more arguments are
+sent positionally as a list, in the same way the server side actor container decodes them.
+An error describing every invalid method of the stub is returned, and the stub is left untouched in that case.
+Stubs generated by cmd/actorgen are bound to this client directly instead.
 */
-func (c *GRPCClient) ImplActorClientStub(actorClientStub actor.Client, opt ...config.Option) {
+func (c *GRPCClient) ImplActorClientStub(actorClientStub actor.Client, opt ...config.Option) error {
+	if actorClientStub == nil {
+		return errors.New("actor client stub required")
+	}
+	serializerType := config.GetConfigFromOptions(opt...).SerializerType
+	serializer, err := codec.GetActorCodec(serializerType)
+	if err != nil {
+		return errors.Wrapf(err, "error creating actor client stub %T", actorClientStub)
+	}
+
+	// stubs generated by cmd/actorgen invoke the actor without reflection
+	if bindable, ok := actorClientStub.(actor.BindableClient); ok {
+		bindable.BindInvoker(c.actorInvoker(serializerType), serializer)
+		return nil
+	}
+	return c.implActor(actorClientStub, actorClientStub.ID, serializerType, serializer)
+}
+
+// ImplActorClientStubWithID impls the given client stub @actorClientStub as ImplActorClientStub does, the stub
+// invokes actor @actorID instead of the one its ID method returns.
+func (c *GRPCClient) ImplActorClientStubWithID(actorClientStub actor.Client, actorID string, opt ...config.Option) error {
+	if actorClientStub == nil {
+		return errors.New("actor client stub required")
+	}
+	if actorID == "" {
+		return errors.New("actor client stub actorID required")
+	}
 	serializerType := config.GetConfigFromOptions(opt...).SerializerType
 	serializer, err := codec.GetActorCodec(serializerType)
 	if err != nil {
-		fmt.Printf("[Actor] ERROR: serializer type %s unsupported\n", serializerType)
-		return
+		return errors.Wrapf(err, "error creating actor client stub %T", actorClientStub)
+	}
+
+	if bindable, ok := actorClientStub.(actor.BindableClient); ok {
+		bindable.SetID(actorID)
+		bindable.BindInvoker(c.actorInvoker(serializerType), serializer)
+		return nil
+	}
+	return c.implActor(actorClientStub, func() string {
+		return actorID
+	}, serializerType, serializer)
+}
+
+// actorProxyKey identifies the proxies created by NewActorProxy.
+type actorProxyKey struct {
+	stubType       reflect.Type
+	actorType      string
+	actorID        string
+	serializerType string
+}
+
+// actorProxyCache is a least recently used cache of the proxies created by NewActorProxy.
+type actorProxyCache struct {
+	lock    sync.Mutex
+	size    int
+	order   *list.List
+	entries map[actorProxyKey]*list.Element
+}
+
+// actorProxyEntry is an element of actorProxyCache.order.
+type actorProxyEntry struct {
+	key   actorProxyKey
+	proxy actor.Client
+}
+
+func newActorProxyCache(size int) *actorProxyCache {
+	return &actorProxyCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[actorProxyKey]*list.Element),
+	}
+}
+
+// get returns the proxy cached as @key, and marks it as the most recently used one.
+func (p *actorProxyCache) get(key actorProxyKey) (actor.Client, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	elem, ok := p.entries[key]
+	if !ok {
+		return nil, false
 	}
+	p.order.MoveToFront(elem)
+	return elem.Value.(*actorProxyEntry).proxy, true
+}
+
+// add caches @proxy as @key unless another proxy is cached as @key already, and returns the cached proxy.
+func (p *actorProxyCache) add(key actorProxyKey, proxy actor.Client) actor.Client {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if elem, ok := p.entries[key]; ok {
+		p.order.MoveToFront(elem)
+		return elem.Value.(*actorProxyEntry).proxy
+	}
+	if p.size <= 0 {
+		return proxy
+	}
+	p.entries[key] = p.order.PushFront(&actorProxyEntry{key: key, proxy: proxy})
+	p.evict()
+	return proxy
+}
+
+// resize sets the maximum number of cached proxies to @size, and evicts the proxies exceeding it.
+func (p *actorProxyCache) resize(size int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.size = size
+	p.evict()
+}
+
+// evict removes the least recently used proxies until the cache holds p.size proxies at most.
+func (p *actorProxyCache) evict() {
+	for p.order.Len() > p.size && p.order.Len() > 0 {
+		elem := p.order.Back()
+		p.order.Remove(elem)
+		delete(p.entries, elem.Value.(*actorProxyEntry).key)
+	}
+}
 
-	c.implActor(actorClientStub, serializer)
+// WithActorProxyCacheSize sets the maximum number of proxies cached by NewActorProxy to @size, the least recently
+// used proxies are evicted beyond it, and 0 disables the cache. It's defaultActorProxyCacheSize by default.
+func (c *GRPCClient) WithActorProxyCacheSize(size int) {
+	c.actorProxies.resize(size)
+}
+
+// NewActorProxy returns a client stub of the same type as @actorClientStub, which invokes actor @actorID. The stub
+// definition is bound to any actor at runtime this way, rather than defining a stub type per actor ID.
+// Proxies are cached per stub type, actor and serializer, up to the size set by WithActorProxyCacheSize, so that a
+// proxy is shared by the callers asking for the same actor. Proxies are safe for concurrent use, they must not be
+// modified.
+func (c *GRPCClient) NewActorProxy(actorClientStub actor.Client, actorID string, opt ...config.Option) (actor.Client, error) {
+	stubValue := reflect.ValueOf(actorClientStub)
+	if actorClientStub == nil || stubValue.Kind() != reflect.Ptr || stubValue.IsNil() || stubValue.Elem().Kind() != reflect.Struct {
+		return nil, errors.Errorf("actor client stub %T is not a pointer to struct", actorClientStub)
+	}
+	key := actorProxyKey{
+		stubType:       stubValue.Type(),
+		actorType:      actorClientStub.Type(),
+		actorID:        actorID,
+		serializerType: config.GetConfigFromOptions(opt...).SerializerType,
+	}
+	if proxy, ok := c.actorProxies.get(key); ok {
+		return proxy, nil
+	}
+	// the proxy is a copy of the stub, so that the fields besides methods, such as the type of generated stubs, are kept
+	proxyValue := reflect.New(stubValue.Elem().Type())
+	proxyValue.Elem().Set(stubValue.Elem())
+	proxy := proxyValue.Interface().(actor.Client)
+	if err := c.ImplActorClientStubWithID(proxy, actorID, opt...); err != nil {
+		return nil, err
+	}
+	return c.actorProxies.add(key, proxy), nil
+}
+
+// actorInvoker returns the actor.Invoker of stubs generated by cmd/actorgen, which declares the codec
+// @serializerType the data is encoded with in the method name.
+func (c *GRPCClient) actorInvoker(serializerType string) actor.Invoker {
+	return func(ctx context.Context, actorType, actorID, method string, data []byte) ([]byte, error) {
+		rsp, err := c.InvokeActor(ctx, &InvokeActorRequest{
+			ActorType: actorType,
+			ActorID:   actorID,
+			Method:    codec.MethodWithCodec(method, serializerType),
+			Data:      data,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return rsp.Data, nil
+	}
 }
 
 type RegisterActorReminderRequest struct {
@@ -104,6 +276,8 @@ type RegisterActorReminderRequest struct {
 	DueTime   string
 	Period    string
 	Data      []byte
+	// TTL expires the reminder, it's a Go duration, an ISO 8601 duration or an RFC 3339 time.
+	TTL string
 }
 
 // RegisterActorReminder registers a new reminder to target actor. Then, a reminder would be created and
@@ -126,8 +300,8 @@ func (c *GRPCClient) RegisterActorReminder(ctx context.Context, in *RegisterActo
 	if in.DueTime == "" {
 		return errors.New("actor register reminder invocation dueTime required")
 	}
-	if in.Period == "" {
-		return errors.New("actor register reminder invocation period required")
+	if err := actor.ValidateSchedule(in.DueTime, in.Period, in.TTL); err != nil {
+		return errors.Wrap(err, "actor register reminder invocation schedule invalid")
 	}
 
 	req := &pb.RegisterActorReminderRequest{
@@ -136,6 +310,7 @@ func (c *GRPCClient) RegisterActorReminder(ctx context.Context, in *RegisterActo
 		Name:      in.Name,
 		DueTime:   in.DueTime,
 		Period:    in.Period,
+		Ttl:       in.TTL,
 		Data:      in.Data,
 	}
 
@@ -188,6 +363,8 @@ type RegisterActorTimerRequest struct {
 	Period    string
 	Data      []byte
 	CallBack  string
+	// TTL expires the timer, it's a Go duration, an ISO 8601 duration or an RFC 3339 time.
+	TTL string
 }
 
 // RegisterActorTimer register actor timer as given param @in defined.
@@ -207,8 +384,8 @@ func (c *GRPCClient) RegisterActorTimer(ctx context.Context, in *RegisterActorTi
 	if in.DueTime == "" {
 		return errors.New("actor register timer invocation dueTime required")
 	}
-	if in.Period == "" {
-		return errors.New("actor register timer invocation period required")
+	if err := actor.ValidateSchedule(in.DueTime, in.Period, in.TTL); err != nil {
+		return errors.Wrap(err, "actor register timer invocation schedule invalid")
 	}
 	if in.CallBack == "" {
 		return errors.New("actor register timer invocation callback function required")
@@ -220,6 +397,7 @@ func (c *GRPCClient) RegisterActorTimer(ctx context.Context, in *RegisterActorTi
 		Name:      in.Name,
 		DueTime:   in.DueTime,
 		Period:    in.Period,
+		Ttl:       in.TTL,
 		Data:      in.Data,
 		Callback:  in.CallBack,
 	}
@@ -266,58 +444,77 @@ func (c *GRPCClient) UnregisterActorTimer(ctx context.Context, in *UnregisterAct
 	return nil
 }
 
-func (c *GRPCClient) implActor(actor actor.Client, serializer codec.Codec) {
+// implActor impls the methods of @actor by reflection, they invoke the actor whose ID @actorID returns.
+func (c *GRPCClient) implActor(actor actor.Client, actorID func() string, serializerType string, serializer codec.Codec) error {
 	actorValue := reflect.ValueOf(actor)
+	// check incoming interface, the incoming interface must be a pointer to struct.
+	if actorValue.Kind() != reflect.Ptr || actorValue.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("actor client stub %T is not a pointer to struct", actor)
+	}
 	valueOfActor := actorValue.Elem()
 	typeOfActor := valueOfActor.Type()
 
-	// check incoming interface, the incoming interface's elem must be a struct.
-	if typeOfActor.Kind() != reflect.Struct {
-		fmt.Println("[Actor] ERROR: imple actor client stub failed, incoming interface is not struct")
-		return
-	}
-
+	invalidFields := make([]string, 0)
+	proxies := make(map[int]reflect.Value)
 	numField := valueOfActor.NumField()
 	for i := 0; i < numField; i++ {
 		t := typeOfActor.Field(i)
 		methodName := t.Name
-		if methodName == "Type" {
+		if methodName == "Type" || t.Type.Kind() != reflect.Func {
 			continue
 		}
 		f := valueOfActor.Field(i)
-		if f.Kind() == reflect.Func && f.IsValid() && f.CanSet() {
-			outNum := t.Type.NumOut()
-
-			if outNum != 1 && outNum != 2 {
-				fmt.Printf("[Actor] ERRROR: method %s of mtype %v has wrong number of in out parameters %d; needs exactly 1/2\n",
-					t.Name, t.Type.String(), outNum)
-				continue
-			}
+		if !f.CanSet() {
+			invalidFields = append(invalidFields, fmt.Sprintf("method %s is not exported", methodName))
+			continue
+		}
+		outNum := t.Type.NumOut()
+		if outNum != 1 && outNum != 2 {
+			invalidFields = append(invalidFields, fmt.Sprintf("method %s of type %s has %d out parameters, needs exactly 1/2",
+				methodName, t.Type, outNum))
+			continue
+		}
+		// The latest return type of the method must be error.
+		if returnType := t.Type.Out(outNum - 1); returnType != typeOfError {
+			invalidFields = append(invalidFields, fmt.Sprintf("the latest return type %s of method %s is not error", returnType, methodName))
+			continue
+		}
 
-			// The latest return type of the method must be error.
-			if returnType := t.Type.Out(outNum - 1); returnType != reflect.Zero(reflect.TypeOf((*error)(nil)).Elem()).Type() {
-				fmt.Printf("[Actor] ERRROR: the latest return type %s of method %q is not error\n", returnType, t.Name)
-				continue
-			}
+		funcOuts := make([]reflect.Type, outNum)
+		for i := 0; i < outNum; i++ {
+			funcOuts[i] = t.Type.Out(i)
+		}
+		proxies[i] = reflect.MakeFunc(f.Type(), c.makeCallProxyFunction(actor, actorID, methodName, funcOuts, serializerType, serializer))
+	}
+	if len(invalidFields) > 0 {
+		return errors.Errorf("invalid actor client stub %T: %s", actor, strings.Join(invalidFields, "; "))
+	}
 
-			funcOuts := make([]reflect.Type, outNum)
-			for i := 0; i < outNum; i++ {
-				funcOuts[i] = t.Type.Out(i)
-			}
+	// the stub is only changed when all of its methods are valid
+	for i, proxy := range proxies {
+		valueOfActor.Field(i).Set(proxy)
+	}
+	return nil
+}
 
-			f.Set(reflect.MakeFunc(f.Type(), c.makeCallProxyFunction(actor, methodName, funcOuts, serializer)))
+func (c *GRPCClient) makeCallProxyFunction(actor actor.Client, actorID func() string, methodName string, outs []reflect.Type, serializerType string, serializer codec.Codec) func(in []reflect.Value) []reflect.Value {
+	// returnValues builds the results of the proxy from the reply pointer @reply and error @err.
+	returnValues := func(reply reflect.Value, err error) []reflect.Value {
+		errValue := reflect.ValueOf(&err).Elem()
+		if len(outs) == 1 {
+			return []reflect.Value{errValue}
 		}
+		if err != nil {
+			return []reflect.Value{reflect.Zero(outs[0]), errValue}
+		}
+		if outs[0].Kind() != reflect.Ptr {
+			return []reflect.Value{reply.Elem(), errValue}
+		}
+		return []reflect.Value{reply, errValue}
 	}
-}
 
-func (c *GRPCClient) makeCallProxyFunction(actor actor.Client, methodName string, outs []reflect.Type, serializer codec.Codec) func(in []reflect.Value) []reflect.Value {
 	return func(in []reflect.Value) []reflect.Value {
-		var (
-			err    error
-			inIArr []interface{}
-			reply  reflect.Value
-		)
-
+		var reply reflect.Value
 		if len(outs) == 2 {
 			if outs[0].Kind() == reflect.Ptr {
 				reply = reflect.New(outs[0].Elem())
@@ -338,44 +535,32 @@ func (c *GRPCClient) makeCallProxyFunction(actor actor.Client, methodName string
 			}
 		}
 
-		if end-start <= 0 {
-			inIArr = []interface{}{}
-		} else if end-start == 1 {
-			inIArr = []interface{}{in[start].Interface()}
-		} else {
-			fmt.Println("[Actor] ERROR: param nums is zero or one is allowed by actor")
-			return nil
+		inIArr := make([]interface{}, 0, end-start)
+		for _, v := range in[start:] {
+			inIArr = append(inIArr, v.Interface())
 		}
 
-		var data []byte
-		if len(inIArr) > 0 {
-			data, err = json.Marshal(inIArr[0])
-		}
+		// arguments are encoded positionally with the codec of the stub, a single argument as it is and more arguments
+		// as a list
+		data, err := codec.MarshalArgs(serializer, inIArr)
 		if err != nil {
-			panic(err)
+			return returnValues(reply, errors.Wrapf(err, "error marshaling arguments of actor method %s", methodName))
 		}
 
 		rsp, err := c.InvokeActor(invCtx, &InvokeActorRequest{
 			ActorType: actor.Type(),
-			ActorID:   actor.ID(),
-			Method:    methodName,
+			ActorID:   actorID(),
+			Method:    codec.MethodWithCodec(methodName, serializerType),
 			Data:      data,
 		})
-
-		if len(outs) == 1 {
-			return []reflect.Value{reflect.ValueOf(&err).Elem()}
+		if err != nil || len(outs) == 1 {
+			return returnValues(reply, err)
 		}
 
-		response := reply.Interface()
-		if rsp != nil {
-			if err = serializer.Unmarshal(rsp.Data, response); err != nil {
-				fmt.Printf("[Actor] ERROR: unmarshal response err = %v\n", err)
-			}
-		}
-		if len(outs) == 2 && outs[0].Kind() != reflect.Ptr {
-			return []reflect.Value{reply.Elem(), reflect.ValueOf(&err).Elem()}
+		if err = serializer.Unmarshal(rsp.Data, reply.Interface()); err != nil {
+			return returnValues(reply, errors.Wrapf(err, "error unmarshaling response of actor method %s", methodName))
 		}
-		return []reflect.Value{reply, reflect.ValueOf(&err).Elem()}
+		return returnValues(reply, nil)
 	}
 }
 
@@ -2,9 +2,14 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/dapr/go-sdk/actor"
+	"github.com/dapr/go-sdk/actor/config"
 )
 
 const testActorType = "test"
@@ -104,10 +109,31 @@ func TestRegisterActorReminder(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 
+	t.Run("invoke register actor reminder with invalid period ", func(t *testing.T) {
+		in.Period = "R5/10 seconds"
+		err := testClient.RegisterActorReminder(ctx, in)
+		in.Period = "2s"
+		assert.NotNil(t, err)
+	})
+
 	t.Run("invoke register actor reminder ", func(t *testing.T) {
 		assert.Nil(t, testClient.RegisterActorReminder(ctx, in))
 	})
 
+	t.Run("invoke register actor reminder with repetitions and ttl ", func(t *testing.T) {
+		in.Period, in.TTL = "R5/PT2S", "1m"
+		err := testClient.RegisterActorReminder(ctx, in)
+		in.Period, in.TTL = "2s", ""
+		assert.Nil(t, err)
+	})
+
+	t.Run("invoke register actor reminder once ", func(t *testing.T) {
+		in.Period = ""
+		err := testClient.RegisterActorReminder(ctx, in)
+		in.Period = "2s"
+		assert.Nil(t, err)
+	})
+
 	t.Run("invoke register actor reminder with empty param", func(t *testing.T) {
 		assert.NotNil(t, testClient.RegisterActorReminder(ctx, nil))
 	})
@@ -174,6 +200,13 @@ func TestRegisterActorTimer(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("invoke register actor timer with ttl before due time", func(t *testing.T) {
+		in.TTL = "1s"
+		err := testClient.RegisterActorTimer(ctx, in)
+		in.TTL = ""
+		assert.NotNil(t, err)
+	})
+
 	t.Run("invoke register actor timer", func(t *testing.T) {
 		assert.Nil(t, testClient.RegisterActorTimer(ctx, in))
 	})
@@ -272,3 +305,273 @@ func TestUnregisterActorTimer(t *testing.T) {
 		assert.NotNil(t, testClient.UnregisterActorTimer(ctx, nil))
 	})
 }
+
+type testActorClientStub struct {
+	Echo         func(context.Context, string, int) ([]interface{}, error)
+	EchoOne      func(context.Context, string) (int, error)
+	EchoChan     func(context.Context, chan int) (string, error)
+	EchoNoCtx    func(string, int) ([]interface{}, error)
+	EchoNoParams func(context.Context) error
+	WhoAmI       func(context.Context) (string, error)
+	WhichCodec   func(context.Context) (string, error)
+}
+
+func (a *testActorClientStub) Type() string {
+	return testActorType
+}
+
+func (a *testActorClientStub) ID() string {
+	return "fn"
+}
+
+func TestSaveStateTransactionally(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("save state without operations", func(t *testing.T) {
+		err := testClient.SaveStateTransactionally(ctx, testActorType, "fn", nil)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestImplActorClientStub(t *testing.T) {
+	ctx := context.Background()
+	stub := &testActorClientStub{}
+	assert.Nil(t, testClient.ImplActorClientStub(stub))
+
+	t.Run("invoke actor stub with multiple args", func(t *testing.T) {
+		rsp, err := stub.Echo(ctx, "hello", 1)
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{"hello", float64(1)}, rsp)
+	})
+
+	t.Run("invoke actor stub with multiple args without context", func(t *testing.T) {
+		rsp, err := stub.EchoNoCtx("hello", 1)
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{"hello", float64(1)}, rsp)
+	})
+
+	t.Run("invoke actor stub without params", func(t *testing.T) {
+		assert.Nil(t, stub.EchoNoParams(ctx))
+	})
+}
+
+type testInvalidActorClientStub struct {
+	NoError     func(context.Context) string
+	TooManyOuts func(context.Context) (string, string, error)
+	notExported func(context.Context) error
+	Valid       func(context.Context) error
+}
+
+func (a *testInvalidActorClientStub) Type() string {
+	return testActorType
+}
+
+func (a *testInvalidActorClientStub) ID() string {
+	return "fn"
+}
+
+func TestImplInvalidActorClientStub(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("impl actor stub with invalid methods", func(t *testing.T) {
+		stub := &testInvalidActorClientStub{}
+		err := testClient.ImplActorClientStub(stub)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "the latest return type string of method NoError is not error")
+		assert.Contains(t, err.Error(), "method TooManyOuts of type func(context.Context) (string, string, error) has 3 out parameters")
+		assert.Contains(t, err.Error(), "method notExported is not exported")
+		assert.Nil(t, stub.Valid)
+	})
+
+	t.Run("impl actor stub with unsupported serializer", func(t *testing.T) {
+		assert.NotNil(t, testClient.ImplActorClientStub(&testActorClientStub{}, config.WithSerializerName("unsupported")))
+	})
+
+	t.Run("impl nil actor stub", func(t *testing.T) {
+		assert.NotNil(t, testClient.ImplActorClientStub(nil))
+		var stub *testActorClientStub
+		assert.NotNil(t, testClient.ImplActorClientStub(stub))
+	})
+
+	t.Run("invoke actor stub with unmarshalable argument", func(t *testing.T) {
+		stub := &testActorClientStub{}
+		assert.Nil(t, testClient.ImplActorClientStub(stub))
+		rsp, err := stub.EchoChan(ctx, make(chan int))
+		assert.NotNil(t, err)
+		assert.Equal(t, "", rsp)
+	})
+
+	t.Run("invoke actor stub with undecodable response", func(t *testing.T) {
+		stub := &testActorClientStub{}
+		assert.Nil(t, testClient.ImplActorClientStub(stub))
+		rsp, err := stub.EchoOne(ctx, "not a number")
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, rsp)
+	})
+}
+
+type testGeneratedActorClientStub struct {
+	actor.ClientStubBase
+}
+
+func (s *testGeneratedActorClientStub) Echo(ctx context.Context, arg0 string, arg1 int) ([]interface{}, error) {
+	var reply []interface{}
+	if err := s.ClientStubBase.Invoke(ctx, "Echo", &reply, arg0, arg1); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *testGeneratedActorClientStub) WhichCodec(ctx context.Context) (string, error) {
+	var reply string
+	if err := s.ClientStubBase.Invoke(ctx, "WhichCodec", &reply); err != nil {
+		return "", err
+	}
+	return reply, nil
+}
+
+func (s *testGeneratedActorClientStub) WhoAmI(ctx context.Context) (string, error) {
+	var reply string
+	if err := s.ClientStubBase.Invoke(ctx, "WhoAmI", &reply); err != nil {
+		return "", err
+	}
+	return reply, nil
+}
+
+func TestImplGeneratedActorClientStub(t *testing.T) {
+	ctx := context.Background()
+	stub := &testGeneratedActorClientStub{ClientStubBase: actor.NewClientStubBase(testActorType, "fn")}
+
+	_, err := stub.Echo(ctx, "hello", 1)
+	assert.NotNil(t, err)
+
+	assert.Nil(t, testClient.ImplActorClientStub(stub))
+	rsp, err := stub.Echo(ctx, "hello", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"hello", float64(1)}, rsp)
+}
+
+func TestActorClientStubCodec(t *testing.T) {
+	ctx := context.Background()
+
+	// requests are encoded with the codec of the stub, which is declared to the actor server
+	stub := &testActorClientStub{}
+	assert.Nil(t, testClient.ImplActorClientStub(stub, config.WithSerializerName("yaml")))
+	rsp, err := stub.Echo(ctx, "hello", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"hello", 1}, rsp)
+	codecName, err := stub.WhichCodec(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "yaml", codecName)
+
+	generated := &testGeneratedActorClientStub{ClientStubBase: actor.NewClientStubBase(testActorType, "fn")}
+	assert.Nil(t, testClient.ImplActorClientStub(generated, config.WithSerializerName("yaml")))
+	rsp, err = generated.Echo(ctx, "hello", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"hello", 1}, rsp)
+	codecName, err = generated.WhichCodec(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "yaml", codecName)
+
+	out, err := testClient.InvokeActor(ctx, &InvokeActorRequest{ActorType: testActorType, ActorID: "fn", Method: "WhichCodec"})
+	assert.Nil(t, err)
+	assert.Equal(t, `""`, string(out.Data))
+}
+
+func TestImplActorClientStubWithID(t *testing.T) {
+	ctx := context.Background()
+
+	stub := &testActorClientStub{}
+	assert.Nil(t, testClient.ImplActorClientStubWithID(stub, "actor-1"))
+	id, err := stub.WhoAmI(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "actor-1", id)
+
+	generated := &testGeneratedActorClientStub{ClientStubBase: actor.NewClientStubBase(testActorType, "fn")}
+	assert.Nil(t, testClient.ImplActorClientStubWithID(generated, "actor-2"))
+	assert.Equal(t, "actor-2", generated.ID())
+	id, err = generated.WhoAmI(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "actor-2", id)
+
+	assert.NotNil(t, testClient.ImplActorClientStubWithID(nil, "actor-1"))
+	assert.NotNil(t, testClient.ImplActorClientStubWithID(&testActorClientStub{}, ""))
+	assert.NotNil(t, testClient.ImplActorClientStubWithID(&testActorClientStub{}, "actor-1", config.WithSerializerName("unsupported")))
+}
+
+func TestNewActorProxy(t *testing.T) {
+	ctx := context.Background()
+	template := &testActorClientStub{}
+
+	proxies := make([]*testActorClientStub, 10)
+	var wg sync.WaitGroup
+	for i := range proxies {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			proxy, err := testClient.NewActorProxy(template, fmt.Sprintf("actor-%d", i%2))
+			assert.Nil(t, err)
+			proxies[i] = proxy.(*testActorClientStub)
+		}(i)
+	}
+	wg.Wait()
+
+	// proxies are cached per actor ID, and the template is left untouched
+	assert.Same(t, proxies[0], proxies[2])
+	assert.Same(t, proxies[1], proxies[3])
+	assert.NotSame(t, proxies[0], proxies[1])
+	assert.Nil(t, template.WhoAmI)
+	for i, proxy := range proxies {
+		id, err := proxy.WhoAmI(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprintf("actor-%d", i%2), id)
+	}
+
+	generatedTemplate := &testGeneratedActorClientStub{ClientStubBase: actor.NewClientStubBase(testActorType, "fn")}
+	generated, err := testClient.NewActorProxy(generatedTemplate, "actor-3")
+	assert.Nil(t, err)
+	assert.Equal(t, testActorType, generated.Type())
+	assert.Equal(t, "fn", generatedTemplate.ID())
+	id, err := generated.(*testGeneratedActorClientStub).WhoAmI(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "actor-3", id)
+
+	_, err = testClient.NewActorProxy(nil, "actor-1")
+	assert.NotNil(t, err)
+	_, err = testClient.NewActorProxy(template, "")
+	assert.NotNil(t, err)
+}
+
+func TestActorProxyCache(t *testing.T) {
+	cache := newActorProxyCache(2)
+	keys := make([]actorProxyKey, 3)
+	proxies := make([]actor.Client, 3)
+	for i := range keys {
+		keys[i] = actorProxyKey{actorType: testActorType, actorID: fmt.Sprintf("actor-%d", i)}
+		proxies[i] = &testActorClientStub{}
+	}
+
+	assert.Same(t, proxies[0], cache.add(keys[0], proxies[0]))
+	assert.Same(t, proxies[0], cache.add(keys[0], proxies[1]))
+	assert.Same(t, proxies[1], cache.add(keys[1], proxies[1]))
+	// actor-0 is used more recently than actor-1, which is evicted
+	_, ok := cache.get(keys[0])
+	assert.True(t, ok)
+	cache.add(keys[2], proxies[2])
+	_, ok = cache.get(keys[1])
+	assert.False(t, ok)
+	proxy, ok := cache.get(keys[0])
+	assert.True(t, ok)
+	assert.Same(t, proxies[0], proxy)
+
+	// resizing evicts the proxies beyond the size, and 0 disables the cache
+	cache.resize(1)
+	_, ok = cache.get(keys[2])
+	assert.False(t, ok)
+	cache.resize(0)
+	assert.Same(t, proxies[1], cache.add(keys[1], proxies[1]))
+	_, ok = cache.get(keys[1])
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.order.Len())
+	assert.Len(t, cache.entries, 0)
+}
@@ -133,7 +133,16 @@ type Client interface {
 	SaveStateTransactionally(ctx context.Context, actorType, actorID string, operations []*ActorStateOperation) error
 
 	// ImplActorClientStub is to impl user defined actor client stub
-	ImplActorClientStub(actorClientStub actor.Client, opt ...config.Option)
+	ImplActorClientStub(actorClientStub actor.Client, opt ...config.Option) error
+
+	// ImplActorClientStubWithID impls user defined actor client stub targeting the given actor ID
+	ImplActorClientStubWithID(actorClientStub actor.Client, actorID string, opt ...config.Option) error
+
+	// NewActorProxy returns a cached client stub of the same type as the given one, targeting the given actor ID
+	NewActorProxy(actorClientStub actor.Client, actorID string, opt ...config.Option) (actor.Client, error)
+
+	// WithActorProxyCacheSize sets the maximum number of proxies cached by NewActorProxy, 0 disables the cache.
+	WithActorProxyCacheSize(size int)
 }
 
 // NewClient instantiates Dapr client using DAPR_GRPC_PORT environment variable as port.
@@ -205,6 +214,7 @@ func newClientWithConnectionAndCancelFunc(
 		ctxCancelFunc: cancelFunc,
 		protoClient:   pb.NewDaprClient(conn),
 		authToken:     os.Getenv(apiTokenEnvVarName),
+		actorProxies:  newActorProxyCache(defaultActorProxyCacheSize),
 	}
 }
 
@@ -215,6 +225,8 @@ type GRPCClient struct {
 	protoClient   pb.DaprClient
 	authToken     string
 	mux           sync.Mutex
+	// actorProxies caches the actor client stubs created by NewActorProxy
+	actorProxies *actorProxyCache
 }
 
 // Close cleans up all resources created by the client.
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/golang/protobuf/ptypes/empty"
@@ -16,6 +18,7 @@ import (
 
 	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
 	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	"github.com/dapr/go-sdk/actor/codec"
 )
 
 const (
@@ -221,7 +224,22 @@ func (s *testDaprServer) UnregisterActorReminder(ctx context.Context, req *pb.Un
 	return &empty.Empty{}, nil
 }
 
-func (s *testDaprServer) InvokeActor(context.Context, *pb.InvokeActorRequest) (*pb.InvokeActorResponse, error) {
+func (s *testDaprServer) InvokeActor(ctx context.Context, req *pb.InvokeActorRequest) (*pb.InvokeActorResponse, error) {
+	if req.Method == "WhoAmI" {
+		return &pb.InvokeActorResponse{
+			Data: []byte(strconv.Quote(req.ActorId)),
+		}, nil
+	}
+	if method, codecName := codec.SplitMethodCodec(req.Method); method == "WhichCodec" {
+		return &pb.InvokeActorResponse{
+			Data: []byte(strconv.Quote(codecName)),
+		}, nil
+	}
+	if strings.HasPrefix(req.Method, "Echo") {
+		return &pb.InvokeActorResponse{
+			Data: req.Data,
+		}, nil
+	}
 	return &pb.InvokeActorResponse{
 		Data: []byte("mockValue"),
 	}, nil
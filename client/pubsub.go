@@ -10,6 +10,9 @@ import (
 	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
 )
 
+// rawPayloadMetadataKey is the metadata key telling Dapr not to wrap the published data into a CloudEvent.
+const rawPayloadMetadataKey = "rawPayload"
+
 // PublishEventOption is the type for the functional option.
 type PublishEventOption func(*pb.PublishEventRequest)
 
@@ -61,13 +64,34 @@ func PublishEventWithContentType(contentType string) PublishEventOption {
 	}
 }
 
-// PublishEventWithMetadata can be passed as option to PublishEvent to set metadata.
+// PublishEventWithMetadata can be passed as option to PublishEvent to set metadata, it replaces the metadata set by
+// the options before it, such as PublishEventWithRawPayload.
 func PublishEventWithMetadata(metadata map[string]string) PublishEventOption {
 	return func(e *pb.PublishEventRequest) {
 		e.Metadata = metadata
 	}
 }
 
+// PublishEventWithMetadataEntry can be passed as option to PublishEvent to add the metadata @key set to @value to
+// the metadata set by the options before it. The map passed to PublishEventWithMetadata is not changed.
+func PublishEventWithMetadataEntry(key, value string) PublishEventOption {
+	return func(e *pb.PublishEventRequest) {
+		metadata := make(map[string]string, len(e.Metadata)+1)
+		for k, v := range e.Metadata {
+			metadata[k] = v
+		}
+		metadata[key] = value
+		e.Metadata = metadata
+	}
+}
+
+// PublishEventWithRawPayload can be passed as option to PublishEvent to publish the data as is, without wrapping it
+// into a CloudEvent, so that it can be consumed by subscribers not using Dapr. Subscribers using Dapr must subscribe
+// to the topic with raw payloads. It must be passed after PublishEventWithMetadata, which replaces the metadata.
+func PublishEventWithRawPayload() PublishEventOption {
+	return PublishEventWithMetadataEntry(rawPayloadMetadataKey, "true")
+}
+
 // PublishEventfromCustomContent serializes an struct and publishes its contents as data (JSON) onto topic in specific pubsub component.
 // Deprecated: This method is deprecated and will be removed in a future version of the SDK. Please use `PublishEvent` instead.
 func (c *GRPCClient) PublishEventfromCustomContent(ctx context.Context, pubsubName, topicName string, data interface{}) error {
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
 )
 
 type _testCustomContentwithText struct {
@@ -30,6 +32,11 @@ func TestPublishEvent(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("with raw payload", func(t *testing.T) {
+		err := testClient.PublishEvent(ctx, "messages", "test", []byte("ping"), PublishEventWithRawPayload())
+		assert.Nil(t, err)
+	})
+
 	t.Run("without data", func(t *testing.T) {
 		err := testClient.PublishEvent(ctx, "messages", "test", nil)
 		assert.Nil(t, err)
@@ -72,3 +79,27 @@ func TestPublishEvent(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestPublishEventOptions(t *testing.T) {
+	metadata := map[string]string{"key": "value"}
+	request := &pb.PublishEventRequest{}
+	for _, opt := range []PublishEventOption{PublishEventWithMetadata(metadata), PublishEventWithRawPayload()} {
+		opt(request)
+	}
+	// the entries are added to the metadata set before, without changing the metadata passed in
+	assert.Equal(t, map[string]string{"key": "value", "rawPayload": "true"}, request.Metadata)
+	assert.Equal(t, map[string]string{"key": "value"}, metadata)
+
+	// the metadata replaces the one set before
+	request = &pb.PublishEventRequest{}
+	for _, opt := range []PublishEventOption{PublishEventWithRawPayload(), PublishEventWithMetadata(metadata)} {
+		opt(request)
+	}
+	assert.Equal(t, map[string]string{"key": "value"}, request.Metadata)
+
+	request = &pb.PublishEventRequest{}
+	for _, opt := range []PublishEventOption{PublishEventWithMetadataEntry("a", "1"), PublishEventWithMetadataEntry("b", "2")} {
+		opt(request)
+	}
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, request.Metadata)
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// reservedMethods are methods of actor.ClientStubBase, which can't be declared by the actor interface.
+var reservedMethods = map[string]bool{
+	"Type":        true,
+	"ID":          true,
+	"BindInvoker": true,
+	"SetID":       true,
+}
+
+var majorVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+type actorInterface struct {
+	Package    string
+	Name       string
+	StdImports []string
+	Imports    []string
+	Methods    []*actorMethod
+}
+
+type actorMethod struct {
+	Name      string
+	HasCtx    bool
+	ArgTypes  []string
+	ReplyType string
+}
+
+// Params returns the parameter list of the method, with the arguments named arg0..argN.
+func (m *actorMethod) Params() string {
+	params := make([]string, 0, len(m.ArgTypes)+1)
+	if m.HasCtx {
+		params = append(params, "ctx context.Context")
+	}
+	for i, typ := range m.ArgTypes {
+		params = append(params, fmt.Sprintf("arg%d %s", i, typ))
+	}
+	return strings.Join(params, ", ")
+}
+
+// Args returns the argument names of the method without context.
+func (m *actorMethod) Args() string {
+	args := make([]string, len(m.ArgTypes))
+	for i := range m.ArgTypes {
+		args[i] = fmt.Sprintf("arg%d", i)
+	}
+	return strings.Join(args, ", ")
+}
+
+// ArgPtrs returns pointers to the argument names of the method.
+func (m *actorMethod) ArgPtrs() string {
+	args := make([]string, len(m.ArgTypes))
+	for i := range m.ArgTypes {
+		args[i] = fmt.Sprintf("&arg%d", i)
+	}
+	return strings.Join(args, ", ")
+}
+
+// CallArgs returns the arguments used to call the actor method from the dispatch table.
+func (m *actorMethod) CallArgs() string {
+	if m.HasCtx {
+		return strings.TrimSuffix("ctx, "+m.Args(), ", ")
+	}
+	return m.Args()
+}
+
+// Ctx returns the context passed to the invocation of the stub.
+func (m *actorMethod) Ctx() string {
+	if m.HasCtx {
+		return "ctx"
+	}
+	return "context.Background()"
+}
+
+// generate parses the package in @dir and renders the stub and dispatch table of the interface @typeName.
+func generate(dir, typeName string) ([]byte, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error parsing package %s", dir)
+	}
+
+	for pkgName, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+					if ts.Name.Name != typeName {
+						continue
+					}
+					iface, ok := ts.Type.(*ast.InterfaceType)
+					if !ok {
+						return nil, errors.Errorf("type %s is not an interface", typeName)
+					}
+					ai, err := parseInterface(pkgName, typeName, iface, file)
+					if err != nil {
+						return nil, err
+					}
+					return render(ai)
+				}
+			}
+		}
+	}
+	return nil, errors.Errorf("interface %s not found in %s", typeName, dir)
+}
+
+func parseInterface(pkgName, typeName string, iface *ast.InterfaceType, file *ast.File) (*actorInterface, error) {
+	ai := &actorInterface{
+		Package: pkgName,
+		Name:    typeName,
+	}
+	usedPkgs := make(map[string]bool)
+	for _, field := range iface.Methods.List {
+		if len(field.Names) == 0 {
+			return nil, errors.Errorf("embedded interface %s in %s is not supported", types.ExprString(field.Type), typeName)
+		}
+		name := field.Names[0].Name
+		if reservedMethods[name] {
+			return nil, errors.Errorf("method name %s of %s is reserved", name, typeName)
+		}
+		m, err := parseMethod(name, field.Type.(*ast.FuncType))
+		if err != nil {
+			return nil, errors.Wrapf(err, "method %s of %s", name, typeName)
+		}
+		ai.Methods = append(ai.Methods, m)
+		ast.Inspect(field.Type, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if id, ok := sel.X.(*ast.Ident); ok {
+					usedPkgs[id.Name] = true
+				}
+			}
+			return true
+		})
+	}
+
+	imports := map[string]bool{
+		strconv.Quote("context"):                      true,
+		strconv.Quote("github.com/dapr/go-sdk/actor"): true,
+	}
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		name := importName(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if !usedPkgs[name] {
+			continue
+		}
+		if spec.Name != nil {
+			imports[spec.Name.Name+" "+spec.Path.Value] = true
+		} else {
+			imports[spec.Path.Value] = true
+		}
+	}
+	for imp := range imports {
+		// standard library paths have no dot in their first element
+		importPath := imp[strings.Index(imp, `"`)+1:]
+		if first := strings.SplitN(importPath, "/", 2)[0]; strings.Contains(first, ".") {
+			ai.Imports = append(ai.Imports, imp)
+		} else {
+			ai.StdImports = append(ai.StdImports, imp)
+		}
+	}
+	sort.Strings(ai.StdImports)
+	sort.Strings(ai.Imports)
+	return ai, nil
+}
+
+func parseMethod(name string, fn *ast.FuncType) (*actorMethod, error) {
+	m := &actorMethod{Name: name}
+	if fn.Params != nil {
+		for i, field := range fn.Params.List {
+			if _, ok := field.Type.(*ast.Ellipsis); ok {
+				return nil, errors.New("variadic arguments are not supported")
+			}
+			typ := types.ExprString(field.Type)
+			count := len(field.Names)
+			if count == 0 {
+				count = 1
+			}
+			for j := 0; j < count; j++ {
+				if i == 0 && j == 0 && typ == "context.Context" {
+					m.HasCtx = true
+					continue
+				}
+				m.ArgTypes = append(m.ArgTypes, typ)
+			}
+		}
+	}
+
+	results := make([]string, 0, 2)
+	if fn.Results != nil {
+		for _, field := range fn.Results.List {
+			count := len(field.Names)
+			if count == 0 {
+				count = 1
+			}
+			for j := 0; j < count; j++ {
+				results = append(results, types.ExprString(field.Type))
+			}
+		}
+	}
+	if len(results) == 0 || len(results) > 2 || results[len(results)-1] != "error" {
+		return nil, errors.New("must return error or (reply, error)")
+	}
+	if len(results) == 2 {
+		m.ReplyType = results[0]
+	}
+	return m, nil
+}
+
+// importName guesses the package name of @importPath from its last element.
+func importName(importPath string) string {
+	name := path.Base(importPath)
+	if majorVersion.MatchString(name) {
+		name = path.Base(path.Dir(importPath))
+	}
+	if i := strings.Index(name, ".v"); i > 0 {
+		name = name[:i]
+	}
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+func render(ai *actorInterface) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, ai); err != nil {
+		return nil, errors.Wrap(err, "error executing template")
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error formatting generated code:\n%s", buf.String())
+	}
+	return src, nil
+}
+
+var tmpl = template.Must(template.New("actorgen").Parse(`// Code generated by actorgen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+{{- range .StdImports }}
+	{{ . }}
+{{- end }}
+{{ range .Imports }}
+	{{ . }}
+{{- end }}
+)
+
+// {{ .Name }}Stub is the generated client stub of {{ .Name }}, bind it to a Dapr client with ImplActorClientStub.
+type {{ .Name }}Stub struct {
+	actor.ClientStubBase
+}
+
+// New{{ .Name }}Stub creates a client stub of {{ .Name }} targeting actor @actorID of type @actorType.
+func New{{ .Name }}Stub(actorType, actorID string) *{{ .Name }}Stub {
+	return &{{ .Name }}Stub{ClientStubBase: actor.NewClientStubBase(actorType, actorID)}
+}
+
+var _ {{ .Name }} = (*{{ .Name }}Stub)(nil)
+{{ range .Methods }}
+func (s *{{ $.Name }}Stub) {{ .Name }}({{ .Params }}) {{ if .ReplyType }}({{ .ReplyType }}, error){{ else }}error{{ end }} {
+{{- if .ReplyType }}
+	var reply {{ .ReplyType }}
+	if err := s.ClientStubBase.Invoke({{ .Ctx }}, "{{ .Name }}", &reply{{ if .ArgTypes }}, {{ .Args }}{{ end }}); err != nil {
+		var zero {{ .ReplyType }}
+		return zero, err
+	}
+	return reply, nil
+{{- else }}
+	return s.ClientStubBase.Invoke({{ .Ctx }}, "{{ .Name }}", nil{{ if .ArgTypes }}, {{ .Args }}{{ end }})
+{{- end }}
+}
+{{ end }}
+// New{{ .Name }}DispatchTable creates the generated dispatch table of @impl. Return it from the DispatchTable method
+// of the actor server to invoke the methods of {{ .Name }} without reflection.
+func New{{ .Name }}DispatchTable(impl {{ .Name }}) actor.DispatchTable {
+	return actor.DispatchTable{
+{{- range .Methods }}
+		"{{ .Name }}": {
+			HasReply: {{ if .ReplyType }}true{{ else }}false{{ end }},
+			Invoke: func({{ if .HasCtx }}ctx{{ else }}_{{ end }} context.Context, {{ if .ArgTypes }}decode{{ else }}_{{ end }} actor.ArgsDecoder) (interface{}, error) {
+{{- range $i, $typ := .ArgTypes }}
+				var arg{{ $i }} {{ $typ }}
+{{- end }}
+{{- if .ArgTypes }}
+				if err := decode({{ .ArgPtrs }}); err != nil {
+					return nil, err
+				}
+{{- end }}
+{{- if .ReplyType }}
+				return impl.{{ .Name }}({{ .CallArgs }})
+{{- else }}
+				return nil, impl.{{ .Name }}({{ .CallArgs }})
+{{- end }}
+			},
+		},
+{{- end }}
+	}
+}
+`))
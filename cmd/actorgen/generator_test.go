@@ -0,0 +1,42 @@
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("generated example is up to date", func(t *testing.T) {
+		src, err := generate("../../examples/actor/api", "Actor")
+		assert.NoError(t, err)
+		expected, err := ioutil.ReadFile("../../examples/actor/api/actor_actorgen.go")
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(src))
+	})
+
+	t.Run("generate with invalid interfaces", func(t *testing.T) {
+		tests := map[string]string{
+			"Missing":       "interface Missing not found",
+			"NotInterface":  "type NotInterface is not an interface",
+			"NoError":       "method Get of NoError: must return error or (reply, error)",
+			"Variadic":      "method Sum of Variadic: variadic arguments are not supported",
+			"Reserved":      "method name ID of Reserved is reserved",
+			"ReservedSetID": "method name SetID of ReservedSetID is reserved",
+			"Embedded":      "embedded interface NoError in Embedded is not supported",
+		}
+		for typeName, expected := range tests {
+			_, err := generate("testdata/invalid", typeName)
+			assert.Error(t, err, typeName)
+			assert.Contains(t, err.Error(), expected, typeName)
+		}
+	})
+}
+
+func TestImportName(t *testing.T) {
+	assert.Equal(t, "context", importName("context"))
+	assert.Equal(t, "api", importName("github.com/dapr/go-sdk/examples/actor/api"))
+	assert.Equal(t, "yaml", importName("gopkg.in/yaml.v3"))
+	assert.Equal(t, "mux", importName("github.com/gorilla/mux/v2"))
+}
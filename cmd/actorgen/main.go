@@ -0,0 +1,48 @@
+// Command actorgen generates a typed client stub and a reflection free server dispatch table from an actor
+// interface. It is meant to be run by go generate in the package declaring the interface:
+//
+//	//go:generate go run github.com/dapr/go-sdk/cmd/actorgen -type MyActor
+//
+// Methods of the interface take an optional leading context.Context followed by any number of arguments, and
+// return either error or (reply, error). For interface MyActor, it generates:
+//
+//   - MyActorStub, a client stub bound to a Dapr client by client.ImplActorClientStub.
+//   - NewMyActorDispatchTable, which actor servers return from their DispatchTable method.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("actorgen: ")
+
+	typeName := flag.String("type", "", "name of the actor interface, required")
+	dir := flag.String("dir", ".", "directory of the package declaring the actor interface")
+	output := flag.String("output", "", "output file name, defaults to <type>_actorgen.go in the package directory")
+	flag.Parse()
+
+	if *typeName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *output == "" {
+		*output = filepath.Join(*dir, fmt.Sprintf("%s_actorgen.go", strings.ToLower(*typeName)))
+	}
+
+	src, err := generate(*dir, *typeName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// the generated source is checked in along the package, so it's readable like the other source files
+	if err := ioutil.WriteFile(*output, src, 0o644); err != nil { //nolint:gosec
+		log.Fatalf("error writing %s: %v", *output, err)
+	}
+}
@@ -0,0 +1,25 @@
+package invalid
+
+import "context"
+
+type NotInterface struct{}
+
+type NoError interface {
+	Get(context.Context) string
+}
+
+type Variadic interface {
+	Sum(context.Context, ...int) (int, error)
+}
+
+type Reserved interface {
+	ID() error
+}
+
+type ReservedSetID interface {
+	SetID(context.Context, string) error
+}
+
+type Embedded interface {
+	NoError
+}
@@ -2,6 +2,22 @@ package api
 
 import "context"
 
+//go:generate go run github.com/dapr/go-sdk/cmd/actorgen -type Actor
+
+// Actor is the interface of the testActorType actor. Its generated ActorStub is the typed alternative to ClientStub,
+// and NewActorDispatchTable lets the serving actor invoke methods without reflection.
+type Actor interface {
+	GetUser(context.Context, *User) (*User, error)
+	Invoke(context.Context, string) (string, error)
+	Get(context.Context) (string, error)
+	Post(context.Context, string) error
+	StartTimer(context.Context, *TimerRequest) error
+	StopTimer(context.Context, *TimerRequest) error
+	StartReminder(context.Context, *ReminderRequest) error
+	StopReminder(context.Context, *ReminderRequest) error
+	IncrementAndGet(ctx context.Context, stateKey string) (*User, error)
+}
+
 type ClientStub struct {
 	GetUser         func(context.Context, *User) (*User, error)
 	Invoke          func(context.Context, string) (string, error)
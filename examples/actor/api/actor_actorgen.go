@@ -0,0 +1,170 @@
+// Code generated by actorgen. DO NOT EDIT.
+
+package api
+
+import (
+	"context"
+
+	"github.com/dapr/go-sdk/actor"
+)
+
+// ActorStub is the generated client stub of Actor, bind it to a Dapr client with ImplActorClientStub.
+type ActorStub struct {
+	actor.ClientStubBase
+}
+
+// NewActorStub creates a client stub of Actor targeting actor @actorID of type @actorType.
+func NewActorStub(actorType, actorID string) *ActorStub {
+	return &ActorStub{ClientStubBase: actor.NewClientStubBase(actorType, actorID)}
+}
+
+var _ Actor = (*ActorStub)(nil)
+
+func (s *ActorStub) GetUser(ctx context.Context, arg0 *User) (*User, error) {
+	var reply *User
+	if err := s.ClientStubBase.Invoke(ctx, "GetUser", &reply, arg0); err != nil {
+		var zero *User
+		return zero, err
+	}
+	return reply, nil
+}
+
+func (s *ActorStub) Invoke(ctx context.Context, arg0 string) (string, error) {
+	var reply string
+	if err := s.ClientStubBase.Invoke(ctx, "Invoke", &reply, arg0); err != nil {
+		var zero string
+		return zero, err
+	}
+	return reply, nil
+}
+
+func (s *ActorStub) Get(ctx context.Context) (string, error) {
+	var reply string
+	if err := s.ClientStubBase.Invoke(ctx, "Get", &reply); err != nil {
+		var zero string
+		return zero, err
+	}
+	return reply, nil
+}
+
+func (s *ActorStub) Post(ctx context.Context, arg0 string) error {
+	return s.ClientStubBase.Invoke(ctx, "Post", nil, arg0)
+}
+
+func (s *ActorStub) StartTimer(ctx context.Context, arg0 *TimerRequest) error {
+	return s.ClientStubBase.Invoke(ctx, "StartTimer", nil, arg0)
+}
+
+func (s *ActorStub) StopTimer(ctx context.Context, arg0 *TimerRequest) error {
+	return s.ClientStubBase.Invoke(ctx, "StopTimer", nil, arg0)
+}
+
+func (s *ActorStub) StartReminder(ctx context.Context, arg0 *ReminderRequest) error {
+	return s.ClientStubBase.Invoke(ctx, "StartReminder", nil, arg0)
+}
+
+func (s *ActorStub) StopReminder(ctx context.Context, arg0 *ReminderRequest) error {
+	return s.ClientStubBase.Invoke(ctx, "StopReminder", nil, arg0)
+}
+
+func (s *ActorStub) IncrementAndGet(ctx context.Context, arg0 string) (*User, error) {
+	var reply *User
+	if err := s.ClientStubBase.Invoke(ctx, "IncrementAndGet", &reply, arg0); err != nil {
+		var zero *User
+		return zero, err
+	}
+	return reply, nil
+}
+
+// NewActorDispatchTable creates the generated dispatch table of @impl. Return it from the DispatchTable method
+// of the actor server to invoke the methods of Actor without reflection.
+func NewActorDispatchTable(impl Actor) actor.DispatchTable {
+	return actor.DispatchTable{
+		"GetUser": {
+			HasReply: true,
+			Invoke: func(ctx context.Context, decode actor.ArgsDecoder) (interface{}, error) {
+				var arg0 *User
+				if err := decode(&arg0); err != nil {
+					return nil, err
+				}
+				return impl.GetUser(ctx, arg0)
+			},
+		},
+		"Invoke": {
+			HasReply: true,
+			Invoke: func(ctx context.Context, decode actor.ArgsDecoder) (interface{}, error) {
+				var arg0 string
+				if err := decode(&arg0); err != nil {
+					return nil, err
+				}
+				return impl.Invoke(ctx, arg0)
+			},
+		},
+		"Get": {
+			HasReply: true,
+			Invoke: func(ctx context.Context, _ actor.ArgsDecoder) (interface{}, error) {
+				return impl.Get(ctx)
+			},
+		},
+		"Post": {
+			HasReply: false,
+			Invoke: func(ctx context.Context, decode actor.ArgsDecoder) (interface{}, error) {
+				var arg0 string
+				if err := decode(&arg0); err != nil {
+					return nil, err
+				}
+				return nil, impl.Post(ctx, arg0)
+			},
+		},
+		"StartTimer": {
+			HasReply: false,
+			Invoke: func(ctx context.Context, decode actor.ArgsDecoder) (interface{}, error) {
+				var arg0 *TimerRequest
+				if err := decode(&arg0); err != nil {
+					return nil, err
+				}
+				return nil, impl.StartTimer(ctx, arg0)
+			},
+		},
+		"StopTimer": {
+			HasReply: false,
+			Invoke: func(ctx context.Context, decode actor.ArgsDecoder) (interface{}, error) {
+				var arg0 *TimerRequest
+				if err := decode(&arg0); err != nil {
+					return nil, err
+				}
+				return nil, impl.StopTimer(ctx, arg0)
+			},
+		},
+		"StartReminder": {
+			HasReply: false,
+			Invoke: func(ctx context.Context, decode actor.ArgsDecoder) (interface{}, error) {
+				var arg0 *ReminderRequest
+				if err := decode(&arg0); err != nil {
+					return nil, err
+				}
+				return nil, impl.StartReminder(ctx, arg0)
+			},
+		},
+		"StopReminder": {
+			HasReply: false,
+			Invoke: func(ctx context.Context, decode actor.ArgsDecoder) (interface{}, error) {
+				var arg0 *ReminderRequest
+				if err := decode(&arg0); err != nil {
+					return nil, err
+				}
+				return nil, impl.StopReminder(ctx, arg0)
+			},
+		},
+		"IncrementAndGet": {
+			HasReply: true,
+			Invoke: func(ctx context.Context, decode actor.ArgsDecoder) (interface{}, error) {
+				var arg0 string
+				if err := decode(&arg0); err != nil {
+					return nil, err
+				}
+				return impl.IncrementAndGet(ctx, arg0)
+			},
+		},
+	}
+}
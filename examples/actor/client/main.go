@@ -19,9 +19,12 @@ func main() {
 	}
 	defer client.Close()
 
-	// implement actor client stub
-	myActor := new(api.ClientStub)
-	client.ImplActorClientStub(myActor)
+	// implement actor client stub, the proxy invokes the actor of the given ID rather than the one ClientStub.ID returns
+	proxy, err := client.NewActorProxy(new(api.ClientStub), "ActorImplID123456")
+	if err != nil {
+		panic(err)
+	}
+	myActor := proxy.(*api.ClientStub)
 
 	// Invoke user defined method GetUser with user defined param api.User and response
 	// using default serializer type json
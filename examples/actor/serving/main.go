@@ -2,11 +2,14 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/dapr/go-sdk/actor"
+	"github.com/dapr/go-sdk/actor/runtime"
 	dapr "github.com/dapr/go-sdk/client"
 	"github.com/dapr/go-sdk/examples/actor/api"
 
@@ -14,62 +17,47 @@ import (
 )
 
 func testActorFactory() actor.Server {
-	client, err := dapr.NewClient()
-	if err != nil {
-		panic(err)
-	}
-	return &TestActor{
-		daprClient: client,
-	}
+	return &TestActor{}
 }
 
 type TestActor struct {
 	actor.ServerImplBase
-	daprClient dapr.Client
 }
 
 func (t *TestActor) Type() string {
 	return "testActorType"
 }
 
+var _ api.Actor = (*TestActor)(nil)
+
+// DispatchTable returns the generated dispatch table of api.Actor, so methods are invoked without reflection.
+func (t *TestActor) DispatchTable() actor.DispatchTable {
+	return api.NewActorDispatchTable(t)
+}
+
 // user defined functions
 func (t *TestActor) StopTimer(ctx context.Context, req *api.TimerRequest) error {
-	return t.daprClient.UnregisterActorTimer(ctx, &dapr.UnregisterActorTimerRequest{
-		ActorType: t.Type(),
-		ActorID:   t.ID(),
-		Name:      req.TimerName,
-	})
+	return t.UnregisterTimer(ctx, req.TimerName)
 }
 
 func (t *TestActor) StartTimer(ctx context.Context, req *api.TimerRequest) error {
-	return t.daprClient.RegisterActorTimer(ctx, &dapr.RegisterActorTimerRequest{
-		ActorType: t.Type(),
-		ActorID:   t.ID(),
-		Name:      req.TimerName,
-		DueTime:   req.Duration,
-		Period:    req.Period,
-		Data:      []byte(req.Data),
-		CallBack:  req.CallBack,
-	})
+	dueTime, period, err := parseSchedule(req.Duration, req.Period)
+	if err != nil {
+		return err
+	}
+	return t.RegisterTimer(ctx, req.TimerName, dueTime, period, req.CallBack, json.RawMessage(req.Data))
 }
 
 func (t *TestActor) StartReminder(ctx context.Context, req *api.ReminderRequest) error {
-	return t.daprClient.RegisterActorReminder(ctx, &dapr.RegisterActorReminderRequest{
-		ActorType: t.Type(),
-		ActorID:   t.ID(),
-		Name:      req.ReminderName,
-		DueTime:   req.Duration,
-		Period:    req.Period,
-		Data:      []byte(req.Data),
-	})
+	dueTime, period, err := parseSchedule(req.Duration, req.Period)
+	if err != nil {
+		return err
+	}
+	return t.RegisterReminder(ctx, req.ReminderName, dueTime, period, json.RawMessage(req.Data))
 }
 
 func (t *TestActor) StopReminder(ctx context.Context, req *api.ReminderRequest) error {
-	return t.daprClient.UnregisterActorReminder(ctx, &dapr.UnregisterActorReminderRequest{
-		ActorType: t.Type(),
-		ActorID:   t.ID(),
-		Name:      req.ReminderName,
-	})
+	return t.UnregisterReminder(ctx, req.ReminderName)
 }
 
 func (t *TestActor) Invoke(ctx context.Context, req string) (string, error) {
@@ -93,14 +81,9 @@ func (t *TestActor) Post(ctx context.Context, req string) error {
 
 func (t *TestActor) IncrementAndGet(ctx context.Context, stateKey string) (*api.User, error) {
 	stateData := api.User{}
-	if exist, err := t.GetStateManager().Contains(stateKey); err != nil {
-		fmt.Println("state manager call contains with key " + stateKey + "err = " + err.Error())
+	if _, err := t.GetStateManager().TryGet(stateKey, &stateData); err != nil {
+		fmt.Println("state manager call try get with key " + stateKey + "err = " + err.Error())
 		return &stateData, err
-	} else if exist {
-		if err := t.GetStateManager().Get(stateKey, &stateData); err != nil {
-			fmt.Println("state manager call get with key " + stateKey + "err = " + err.Error())
-			return &stateData, err
-		}
 	}
 	stateData.Age++
 	if err := t.GetStateManager().Set(stateKey, stateData); err != nil {
@@ -110,13 +93,33 @@ func (t *TestActor) IncrementAndGet(ctx context.Context, stateKey string) (*api.
 	return &stateData, nil
 }
 
-func (t *TestActor) ReminderCall(reminderName string, state []byte, dueTime string, period string) {
-	fmt.Println("receive reminder = ", reminderName, " state = ", string(state), "duetime = ", dueTime, "period = ", period)
+func (t *TestActor) HandleReminder(ctx context.Context, reminder *actor.Reminder) error {
+	fmt.Println("receive reminder = ", reminder.Name, " state = ", string(reminder.Data), "duetime = ", reminder.DueTime, "period = ", reminder.Period)
+	return nil
+}
+
+func parseSchedule(dueTime, period string) (time.Duration, time.Duration, error) {
+	due, err := time.ParseDuration(dueTime)
+	if err != nil {
+		return 0, 0, err
+	}
+	every, err := time.ParseDuration(period)
+	if err != nil {
+		return 0, 0, err
+	}
+	return due, every, nil
 }
 
 func main() {
-	s := daprd.NewService(":8080")
-	s.RegisterActorImplFactory(testActorFactory)
+	client, err := dapr.NewClient()
+	if err != nil {
+		panic(err)
+	}
+	// actors of the runtime share the client to access their state
+	s := daprd.NewService(":8080", daprd.WithActorRuntime(runtime.NewActorRuntimeWithClient(client)))
+	if err := s.RegisterActor("testActorType", testActorFactory); err != nil {
+		log.Fatalf("error registering actor: %v", err)
+	}
 	if err := s.Start(); err != nil && err != http.ErrServerClosed {
 		log.Fatalf("error listenning: %v", err)
 	}
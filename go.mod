@@ -14,5 +14,9 @@ require (
 require (
 	github.com/golang/mock v1.6.0
 	github.com/gorilla/mux v1.8.0
+	github.com/prometheus/client_golang v1.11.1
+	github.com/prometheus/common v0.26.0
+	github.com/vmihailenco/msgpack/v5 v5.3.5
+	google.golang.org/genproto v0.0.0-20210524171403-669157292da3
 	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
 )
@@ -0,0 +1,84 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/dapr/go-sdk/service/internal/contenttype"
+)
+
+// Struct decodes RawData, the content of the event, into @target by DataContentType. A *[]byte target, or a *string
+// target of non JSON content, is set to the content as is. A proto.Message target is decoded from protocol buffers
+// content, and other targets are decoded from JSON content. Text content is decoded as JSON too, as publishers may
+// not set the content type.
+func (e *TopicEvent) Struct(target interface{}) error {
+	switch t := target.(type) {
+	case *[]byte:
+		*t = append([]byte(nil), e.RawData...)
+		return nil
+	case *string:
+		if !contenttype.IsJSON(e.DataContentType) {
+			*t = string(e.RawData)
+			return nil
+		}
+	}
+	switch {
+	case contenttype.IsProtobuf(e.DataContentType):
+		msg, ok := target.(proto.Message)
+		if !ok {
+			return errors.Errorf("%T is not a protocol buffers message, the content type of event %s is %s", target, e.ID, e.DataContentType)
+		}
+		return errors.Wrapf(proto.Unmarshal(e.RawData, msg), "failed to decode event %s", e.ID)
+	case e.DataContentType == "", contenttype.IsJSON(e.DataContentType), contenttype.IsString(e.DataContentType):
+		return errors.Wrapf(json.Unmarshal(e.RawData, target), "failed to decode event %s", e.ID)
+	}
+	return errors.Errorf("content type %s of event %s can't be decoded into %T", e.DataContentType, e.ID, target)
+}
+
+var (
+	contextType    = reflect.TypeOf((*context.Context)(nil)).Elem()
+	topicEventType = reflect.TypeOf((*TopicEvent)(nil))
+	boolType       = reflect.TypeOf(false)
+	errorType      = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// NewTypedTopicEventHandler adapts @fn, a func(ctx context.Context, e *TopicEvent, data T) (retry bool, err error),
+// to a topic event handler, which decodes the data of the events into T by Struct before calling @fn. T may be a
+// pointer, such as a *MyEvent or a generated protocol buffers message. Events whose data can't be decoded are
+// dropped. It fails if @fn is not such a func.
+func NewTypedTopicEventHandler(fn interface{}) (func(ctx context.Context, e *TopicEvent) (retry bool, err error), error) {
+	if fn == nil {
+		return nil, errors.New("typed topic event handler required")
+	}
+	fnValue := reflect.ValueOf(fn)
+	fnType := fnValue.Type()
+	if fnType.Kind() != reflect.Func || fnType.NumIn() != 3 || fnType.NumOut() != 2 ||
+		fnType.In(0) != contextType || fnType.In(1) != topicEventType ||
+		fnType.Out(0) != boolType || fnType.Out(1) != errorType {
+		return nil, errors.Errorf("typed topic event handler must be a func(context.Context, *common.TopicEvent, T) (bool, error), got %s", fnType)
+	}
+	dataType := fnType.In(2)
+	return func(ctx context.Context, e *TopicEvent) (retry bool, err error) {
+		var data reflect.Value
+		if dataType.Kind() == reflect.Ptr {
+			data = reflect.New(dataType.Elem())
+			err = e.Struct(data.Interface())
+		} else {
+			ptr := reflect.New(dataType)
+			err = e.Struct(ptr.Interface())
+			data = ptr.Elem()
+		}
+		if err != nil {
+			return false, err
+		}
+		out := fnValue.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(e), data})
+		if errValue := out[1].Interface(); errValue != nil {
+			err = errValue.(error)
+		}
+		return out[0].Bool(), err
+	}, nil
+}
@@ -0,0 +1,53 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopicEventStruct(t *testing.T) {
+	var v struct {
+		Message string `json:"message"`
+	}
+	e := &TopicEvent{DataContentType: "application/json; charset=utf-8", RawData: []byte(`{"message":"hello"}`)}
+	assert.NoError(t, e.Struct(&v))
+	assert.Equal(t, "hello", v.Message)
+
+	// protocol buffers content is decoded into messages only
+	e = &TopicEvent{DataContentType: "application/x-protobuf", RawData: []byte{0}}
+	assert.Error(t, e.Struct(&v))
+
+	e = &TopicEvent{DataContentType: "application/octet-stream", RawData: []byte{0}}
+	assert.Error(t, e.Struct(&v))
+	var data []byte
+	assert.NoError(t, e.Struct(&data))
+	assert.Equal(t, []byte{0}, data)
+}
+
+func TestNewTypedTopicEventHandler(t *testing.T) {
+	for _, fn := range []interface{}{
+		nil,
+		"not a func",
+		func(ctx context.Context, v *string) (bool, error) { return false, nil },
+		func(ctx context.Context, e *TopicEvent, v *string) error { return nil },
+	} {
+		_, err := NewTypedTopicEventHandler(fn)
+		assert.Error(t, err)
+	}
+
+	fn, err := NewTypedTopicEventHandler(func(ctx context.Context, e *TopicEvent, v []int) (bool, error) {
+		return len(v) > 1, errors.New("retry if many")
+	})
+	assert.NoError(t, err)
+	retry, err := fn(context.Background(), &TopicEvent{DataContentType: "application/json", RawData: []byte(`[1,2]`)})
+	assert.True(t, retry)
+	assert.EqualError(t, err, "retry if many")
+
+	// events which can't be decoded are dropped
+	retry, err = fn(context.Background(), &TopicEvent{DataContentType: "application/json", RawData: []byte(`{`)})
+	assert.False(t, retry)
+	assert.Error(t, err)
+}
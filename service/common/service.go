@@ -18,6 +18,9 @@ type Service interface {
 	AddBindingInvocationHandler(name string, fn func(ctx context.Context, in *BindingEvent) (out []byte, err error)) error
 	// RegisterActorImplFactory Register a new actor to actor runtime of go sdk
 	RegisterActorImplFactory(f actor.Factory, opts ...config.Option)
+	// RegisterActor registers the actor factory as the given actor type to actor runtime of go sdk, it fails if the
+	// actor type is registered already.
+	RegisterActor(actorType string, f actor.Factory, opts ...config.Option) error
 	// Start starts service.
 	Start() error
 	// Stop stops the previously started service.
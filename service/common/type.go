@@ -12,11 +12,13 @@ type TopicEvent struct {
 	Source string `json:"source"`
 	// The content type of data value.
 	DataContentType string `json:"datacontenttype"`
-	// The content of the event.
-	// Note, this is why the gRPC and HTTP implementations need separate structs for cloud events.
+	// The content of the event, decoded by DataContentType the same way by both the HTTP and gRPC services: a JSON
+	// value for JSON content, a string for text and XML content, and []byte for binary content.
 	Data interface{} `json:"data"`
+	// RawData is the content of the event as published, which Struct decodes.
+	RawData []byte `json:"-"`
 	// The base64 encoding content of the event.
-	// Note, this is processing rawPayload and binary content types .
+	// Note, this is processing rawPayload and binary content types, it's set for all binary content.
 	DataBase64 string `json:"data_base64,omitempty"`
 	// Cloud event subject
 	Subject string `json:"subject"`
@@ -64,10 +66,21 @@ type Subscription struct {
 	PubsubName string `json:"pubsubname"`
 	// Topic is the name of the topic
 	Topic string `json:"topic"`
-	// Route is the route of the handler where HTTP topic events should be published (not used in gRPC)
+	// Route is the route of the handler where HTTP topic events should be published, it's the path identifying the
+	// handler in gRPC, which may be empty for the default handler of the topic
 	Route string `json:"route"`
+	// Match is the CEL expression the events delivered to Route match, such as `event.type == "order.created"`,
+	// Route is the default route of the topic if it's empty. Dapr matches the events only if its PubSub.Routing
+	// feature is enabled.
+	Match string `json:"match"`
+	// Priority orders the routes with Match of a topic, the lower the priority the earlier the route is matched
+	Priority int `json:"priority"`
 	// Metadata is the subscription metadata
 	Metadata map[string]string `json:"metadata,omitempty"`
+	// RawPayload subscribes to the events published as raw payloads rather than CloudEvents, such as the ones of
+	// producers not using Dapr. Dapr wraps the payloads into CloudEvents, the handlers are handed the unwrapped payload
+	// as []byte Data, along with the attributes Dapr synthesized. It's the same as the "rawPayload" metadata.
+	RawPayload bool `json:"rawPayload"`
 }
 
 const (
@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"context"
+	"log"
+
+	"github.com/golang/protobuf/ptypes/any"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	cpb "github.com/dapr/dapr/pkg/proto/common/v1"
+	"github.com/dapr/go-sdk/service/internal/actorcallback"
+)
+
+const (
+	// ActorReentrancyIDMetadataKey is the metadata key Dapr identifies the reentrant call chain of an actor
+	// invocation with, the calls of the chain enter the actor while the chain holds it, and it's sent back as a header
+	// as received so that they can be correlated.
+	ActorReentrancyIDMetadataKey = "dapr-reentrancy-id"
+)
+
+// actorCallbackOf returns the actor callback the invocation @in is, ok is false if it's a service invocation.
+func actorCallbackOf(in *cpb.InvokeRequest) (c *actorcallback.Callback, ok bool) {
+	if in.HttpExtension == nil {
+		return nil, false
+	}
+	return actorcallback.Parse(in.HttpExtension.Verb.String(), in.Method)
+}
+
+// onActorCallback runs the actor callback @c, which Dapr sends through OnInvoke. A failed callback answers the
+// status of its actor error, whose error code is the reason of the errdetails.ErrorInfo detail.
+func (s *Server) onActorCallback(ctx context.Context, c *actorcallback.Callback, in *cpb.InvokeRequest) (*cpb.InvokeResponse, error) {
+	if in.Data != nil {
+		c.Data = in.Data.Value
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	header := metadata.MD{}
+	if ids := md.Get(ActorReentrancyIDMetadataKey); len(ids) > 0 {
+		c.ReentrancyID = ids[0]
+		header.Set(ActorReentrancyIDMetadataKey, ids[0])
+	}
+	rspData, err := actorcallback.Dispatch(s.actorRuntime, c)
+	// the header can't be sent if OnInvoke is not called through a gRPC server, which is fine
+	_ = grpc.SetHeader(ctx, header)
+	if err != nil {
+		return nil, actorCallbackError(in, err)
+	}
+	return &cpb.InvokeResponse{
+		Data: &any.Any{
+			Value: rspData,
+		},
+	}, nil
+}
+
+// actorCallbackError converts @err, which the actor callback @in failed with, to a gRPC status error.
+func actorCallbackError(in *cpb.InvokeRequest, err error) error {
+	code := actorcallback.StatusOf(err)
+	log.Printf("actor callback %s %s failed, code = %s, err = %s", in.HttpExtension.Verb, in.Method, code.ErrorCode, err)
+	st, detailErr := status.New(code.GRPCCode, err.Error()).WithDetails(&errdetails.ErrorInfo{
+		Reason: code.ErrorCode,
+	})
+	if detailErr != nil {
+		return status.Error(code.GRPCCode, err.Error())
+	}
+	return st.Err()
+}
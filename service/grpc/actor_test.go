@@ -0,0 +1,127 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	cpb "github.com/dapr/dapr/pkg/proto/common/v1"
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	"github.com/dapr/go-sdk/actor"
+	"github.com/dapr/go-sdk/actor/config"
+	"github.com/dapr/go-sdk/actor/mock"
+	"github.com/dapr/go-sdk/actor/runtime"
+	"github.com/dapr/go-sdk/service/internal/actortest"
+)
+
+// actorTransport sends the actor callbacks of the conformance test suite to a gRPC server.
+type actorTransport struct {
+	*Server
+	client pb.AppCallbackClient
+}
+
+// expectedActorErrCodes are the gRPC codes of the error codes.
+var expectedActorErrCodes = map[string]codes.Code{
+	"ERR_ACTOR_TYPE_NOT_FOUND":          codes.NotFound,
+	"ERR_ACTOR_ID_NOT_FOUND":            codes.NotFound,
+	"ERR_ACTOR_METHOD_NOT_FOUND":        codes.NotFound,
+	"ERR_ACTOR_REMINDER_PARAMS_INVALID": codes.InvalidArgument,
+	"ERR_ACTOR_TIMER_PARAMS_INVALID":    codes.InvalidArgument,
+	"ERR_ACTOR_REMINDER_UNDEFINED":      codes.Unimplemented,
+	"ERR_ACTOR_INVOKE_FAILED":           codes.Internal,
+	"ERR_ACTOR_SERIALIZE_FAILED":        codes.Internal,
+	"ERR_ACTOR_SERIALIZER_NOT_FOUND":    codes.Internal,
+}
+
+// RegisterActorImplFactory registers the actor factory @f to the actor runtime of the server, as the server doesn't
+// register actors itself.
+func (a *actorTransport) RegisterActorImplFactory(f actor.Factory, opts ...config.Option) {
+	a.actorRuntime.RegisterActorFactory(f, opts...)
+}
+
+func (a *actorTransport) Call(t *testing.T, req *actortest.Request) *actortest.Result {
+	ctx := context.Background()
+	if req.ReentrancyID != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, ActorReentrancyIDMetadataKey, req.ReentrancyID)
+	}
+	var header metadata.MD
+	rsp, err := a.client.OnInvoke(ctx, &cpb.InvokeRequest{
+		Method: req.Path,
+		Data:   &any.Any{Value: req.Data},
+		HttpExtension: &cpb.HTTPExtension{
+			Verb: cpb.HTTPExtension_Verb(cpb.HTTPExtension_Verb_value[req.Verb]),
+		},
+	}, grpc.Header(&header))
+
+	result := &actortest.Result{}
+	if ids := header.Get(ActorReentrancyIDMetadataKey); len(ids) > 0 {
+		result.ReentrancyID = ids[0]
+	}
+	if err == nil {
+		result.Data = rsp.GetData().GetValue()
+		return result
+	}
+	st := status.Convert(err)
+	result.Message = st.Message()
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			result.ErrorCode = info.Reason
+		}
+	}
+	assert.Equal(t, expectedActorErrCodes[result.ErrorCode], st.Code())
+	return result
+}
+
+// TestActorCallbackConformance runs the conformance test suite of the actor callbacks through a gRPC server.
+func TestActorCallbackConformance(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+	server := newService(lis, WithActorRuntime(runtime.NewActorRuntimeWithClient(mock.NewDaprClient())))
+	startTestServer(server)
+	defer stopTestServer(t, server)
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	actortest.Run(t, &actorTransport{Server: server, client: pb.NewAppCallbackClient(conn)})
+}
+
+func TestActorRegistration(t *testing.T) {
+	rt := runtime.NewActorRuntime()
+	server := newService(bufconn.Listen(1024*1024), WithActorRuntime(rt))
+
+	// Dapr reads the actor types of an app from /dapr/config, which the app callback API of gRPC apps has no
+	// counterpart of, so actors are not registered
+	err := server.RegisterActor("testActorType", mock.ActorImplFactory)
+	assert.ErrorIs(t, err, ErrActorsNotDiscoverable)
+	server.RegisterActorImplFactory(mock.ActorImplFactory)
+	data, err := rt.GetJSONSerializedConfig()
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "testActorType")
+
+	// the registration is to be supported once the app callback API serves the actor configuration
+	for _, method := range pb.AppCallback_ServiceDesc.Methods {
+		assert.NotContains(t, strings.ToLower(method.MethodName), "config")
+	}
+}
+
+func TestActorCallbackWithoutHTTPExtension(t *testing.T) {
+	server := getTestServer()
+	server.actorRuntime.RegisterActorFactory(mock.ActorImplFactory)
+
+	// actor callbacks are told apart from service invocations by their verb
+	_, err := server.OnInvoke(context.Background(), &cpb.InvokeRequest{Method: "actors/testActorType/testActorID/method/Invoke"})
+	assert.EqualError(t, err, "method not implemented: actors/testActorType/testActorID/method/Invoke")
+}
@@ -59,5 +59,8 @@ func (s *Server) OnInvoke(ctx context.Context, in *cpb.InvokeRequest) (*cpb.Invo
 			},
 		}, nil
 	}
+	if c, ok := actorCallbackOf(in); ok {
+		return s.onActorCallback(ctx, c, in)
+	}
 	return nil, fmt.Errorf("method not implemented: %s", in.Method)
 }
@@ -2,20 +2,33 @@ package grpc
 
 import (
 	"context"
+	"log"
 	"net"
 
 	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
 	"github.com/dapr/go-sdk/actor"
 	"github.com/dapr/go-sdk/actor/config"
+	"github.com/dapr/go-sdk/actor/runtime"
 	"github.com/dapr/go-sdk/service/common"
+	"github.com/dapr/go-sdk/service/internal/topics"
 
 	"github.com/pkg/errors"
 
 	"google.golang.org/grpc"
 )
 
+// Option is option function of Server.
+type Option func(s *Server)
+
+// WithActorRuntime sets the actor runtime of the Server to @rt, instead of a new runtime owned by the Server.
+func WithActorRuntime(rt *runtime.ActorRunTime) Option {
+	return func(s *Server) {
+		s.actorRuntime = rt
+	}
+}
+
 // NewService creates new Service.
-func NewService(address string) (s common.Service, err error) {
+func NewService(address string, opts ...Option) (s common.Service, err error) {
 	if address == "" {
 		return nil, errors.New("nil address")
 	}
@@ -24,42 +37,57 @@ func NewService(address string) (s common.Service, err error) {
 		err = errors.Wrapf(err, "failed to TCP listen on: %s", address)
 		return
 	}
-	s = newService(lis)
+	s = newService(lis, opts...)
 	return
 }
 
 // NewServiceWithListener creates new Service with specific listener.
-func NewServiceWithListener(lis net.Listener) common.Service {
-	return newService(lis)
+func NewServiceWithListener(lis net.Listener, opts ...Option) common.Service {
+	return newService(lis, opts...)
 }
 
-func newService(lis net.Listener) *Server {
-	return &Server{
-		listener:           lis,
-		invokeHandlers:     make(map[string]func(ctx context.Context, in *common.InvocationEvent) (out *common.Content, err error)),
-		topicSubscriptions: make(map[string]*topicEventHandler),
-		bindingHandlers:    make(map[string]func(ctx context.Context, in *common.BindingEvent) (out []byte, err error)),
+func newService(lis net.Listener, opts ...Option) *Server {
+	s := &Server{
+		listener:        lis,
+		invokeHandlers:  make(map[string]func(ctx context.Context, in *common.InvocationEvent) (out *common.Content, err error)),
+		topicRegistrar:  topics.NewRegistrar(),
+		bindingHandlers: make(map[string]func(ctx context.Context, in *common.BindingEvent) (out []byte, err error)),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.actorRuntime == nil {
+		s.actorRuntime = runtime.NewActorRuntime()
+	}
+	return s
 }
 
 // Server is the gRPC service implementation for Dapr.
 type Server struct {
 	pb.UnimplementedAppCallbackServer
-	listener           net.Listener
-	invokeHandlers     map[string]func(ctx context.Context, in *common.InvocationEvent) (out *common.Content, err error)
-	topicSubscriptions map[string]*topicEventHandler
-	bindingHandlers    map[string]func(ctx context.Context, in *common.BindingEvent) (out []byte, err error)
+	listener        net.Listener
+	invokeHandlers  map[string]func(ctx context.Context, in *common.InvocationEvent) (out *common.Content, err error)
+	topicRegistrar  *topics.Registrar
+	bindingHandlers map[string]func(ctx context.Context, in *common.BindingEvent) (out []byte, err error)
+	actorRuntime    *runtime.ActorRunTime
 }
 
+// ErrActorsNotDiscoverable is returned by the actor registration of the Server, Dapr can't discover the actor types
+// of a gRPC app, as the app callback API has no actor configuration, unlike the /dapr/config route of the HTTP
+// service.
+var ErrActorsNotDiscoverable = errors.New("Dapr can't discover the actor types of a gRPC app, register actors to the HTTP service")
+
+// RegisterActorImplFactory doesn't register the actor factory @f, it logs ErrActorsNotDiscoverable.
+// Deprecated: use RegisterActor, which reports registration failures.
 func (s *Server) RegisterActorImplFactory(f actor.Factory, opts ...config.Option) {
-	panic("Actor is not supported by gRPC API")
+	log.Printf("failed to register actor type %s, err = %s", f().Type(), ErrActorsNotDiscoverable)
 }
 
-type topicEventHandler struct {
-	component string
-	topic     string
-	fn        func(ctx context.Context, e *common.TopicEvent) (retry bool, err error)
-	meta      map[string]string
+// RegisterActor fails with ErrActorsNotDiscoverable, as Dapr would never send callbacks to the actors of type
+// @actorType. The callbacks Dapr sends through OnInvoke are still routed to the actor runtime set by
+// WithActorRuntime.
+func (s *Server) RegisterActor(actorType string, f actor.Factory, opts ...config.Option) error {
+	return errors.Wrapf(ErrActorsNotDiscoverable, "failed to register actor type %s", actorType)
 }
 
 // Start registers the server and starts it.
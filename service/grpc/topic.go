@@ -9,6 +9,7 @@ import (
 
 	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
 	"github.com/dapr/go-sdk/service/common"
+	"github.com/dapr/go-sdk/service/internal/topics"
 )
 
 // AddTopicEventHandler appends provided event handler with topic name to the service.
@@ -25,24 +26,28 @@ func (s *Server) AddTopicEventHandler(sub *common.Subscription, fn func(ctx cont
 	if fn == nil {
 		return fmt.Errorf("topic handler required")
 	}
-	key := fmt.Sprintf("%s-%s", sub.PubsubName, sub.Topic)
-	s.topicSubscriptions[key] = &topicEventHandler{
-		component: sub.PubsubName,
-		topic:     sub.Topic,
-		fn:        fn,
-		meta:      sub.Metadata,
-	}
-	return nil
+	return s.topicRegistrar.AddSubscription(sub, fn)
 }
 
 // ListTopicSubscriptions is called by Dapr to get the list of topics in a pubsub component the app wants to subscribe to.
 func (s *Server) ListTopicSubscriptions(ctx context.Context, in *empty.Empty) (*pb.ListTopicSubscriptionsResponse, error) {
 	subs := make([]*pb.TopicSubscription, 0)
-	for _, v := range s.topicSubscriptions {
+	for _, v := range s.topicRegistrar.Subscriptions() {
+		rules := make([]*pb.TopicRule, 0, len(v.Routes.Rules))
+		for _, rule := range v.Routes.Rules {
+			rules = append(rules, &pb.TopicRule{
+				Match: rule.Match,
+				Path:  rule.Path,
+			})
+		}
 		sub := &pb.TopicSubscription{
-			PubsubName: v.component,
-			Topic:      v.topic,
-			Metadata:   v.meta,
+			PubsubName: v.PubsubName,
+			Topic:      v.Topic,
+			Metadata:   v.Metadata,
+			Routes: &pb.TopicRoutes{
+				Rules:   rules,
+				Default: v.Routes.Default,
+			},
 		}
 		subs = append(subs, sub)
 	}
@@ -53,26 +58,17 @@ func (s *Server) ListTopicSubscriptions(ctx context.Context, in *empty.Empty) (*
 }
 
 // OnTopicEvent fired whenever a message has been published to a topic that has been subscribed.
-// Dapr sends published messages in a CloudEvents v1.0 envelope.
+// Dapr sends published messages in a CloudEvents v1.0 envelope, to the path of the route the message matches.
 func (s *Server) OnTopicEvent(ctx context.Context, in *pb.TopicEventRequest) (*pb.TopicEventResponse, error) {
 	if in == nil || in.Topic == "" || in.PubsubName == "" {
 		// this is really Dapr issue more than the event request format.
 		// since Dapr will not get updated until long after this event expires, just drop it
 		return &pb.TopicEventResponse{Status: pb.TopicEventResponse_DROP}, errors.New("pub/sub and topic names required")
 	}
-	key := fmt.Sprintf("%s-%s", in.PubsubName, in.Topic)
-	if h, ok := s.topicSubscriptions[key]; ok {
-		e := &common.TopicEvent{
-			ID:              in.Id,
-			Source:          in.Source,
-			Type:            in.Type,
-			SpecVersion:     in.SpecVersion,
-			DataContentType: in.DataContentType,
-			Data:            in.Data,
-			Topic:           in.Topic,
-			PubsubName:      in.PubsubName,
-		}
-		retry, err := h.fn(ctx, e)
+	if fn, ok := s.topicRegistrar.Handler(in.PubsubName, in.Topic, in.Path); ok {
+		// the data is decoded the same way as the one of the HTTP service
+		e := topics.NewGRPCEvent(in)
+		retry, err := fn(ctx, e)
 		if err == nil {
 			return &pb.TopicEventResponse{Status: pb.TopicEventResponse_SUCCESS}, nil
 		}
@@ -82,7 +78,7 @@ func (s *Server) OnTopicEvent(ctx context.Context, in *pb.TopicEventRequest) (*p
 		return &pb.TopicEventResponse{Status: pb.TopicEventResponse_DROP}, err
 	}
 	return &pb.TopicEventResponse{Status: pb.TopicEventResponse_RETRY}, fmt.Errorf(
-		"pub/sub and topic combination not configured: %s/%s",
-		in.PubsubName, in.Topic,
+		"pub/sub, topic and path combination not configured: %s/%s%s",
+		in.PubsubName, in.Topic, in.Path,
 	)
 }
@@ -10,6 +10,7 @@ import (
 
 	runtime "github.com/dapr/dapr/pkg/proto/runtime/v1"
 	"github.com/dapr/go-sdk/service/common"
+	"github.com/dapr/go-sdk/service/internal/topictest"
 )
 
 func TestTopicErrors(t *testing.T) {
@@ -161,3 +162,93 @@ func eventHandlerWithRetryError(ctx context.Context, event *common.TopicEvent) (
 func eventHandlerWithError(ctx context.Context, event *common.TopicEvent) (retry bool, err error) {
 	return false, errors.New("nil event")
 }
+
+func TestTopicRoutes(t *testing.T) {
+	ctx := context.Background()
+	handled := ""
+	handler := func(path string) func(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
+		return func(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
+			handled = path
+			return false, nil
+		}
+	}
+	server := getTestServer()
+	assert.NoError(t, server.AddTopicEventHandler(&common.Subscription{PubsubName: "messages", Topic: "orders"}, handler("default")))
+	assert.NoError(t, server.AddTopicEventHandler(&common.Subscription{
+		PubsubName: "messages",
+		Topic:      "orders",
+		Route:      "created",
+		Match:      `event.type == "order.created"`,
+	}, handler("created")))
+	assert.Error(t, server.AddTopicEventHandler(&common.Subscription{PubsubName: "messages", Topic: "orders"}, handler("default")))
+
+	resp, err := server.ListTopicSubscriptions(ctx, &empty.Empty{})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Subscriptions, 1)
+	routes := resp.Subscriptions[0].Routes
+	assert.Equal(t, "", routes.Default)
+	assert.Len(t, routes.Rules, 1)
+	assert.Equal(t, `event.type == "order.created"`, routes.Rules[0].Match)
+	assert.Equal(t, "created", routes.Rules[0].Path)
+
+	for path, expected := range map[string]string{"created": "created", "": "default"} {
+		handled = ""
+		_, err := server.OnTopicEvent(ctx, &runtime.TopicEventRequest{PubsubName: "messages", Topic: "orders", Path: path})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, handled)
+	}
+	resp2, err := server.OnTopicEvent(ctx, &runtime.TopicEventRequest{PubsubName: "messages", Topic: "orders", Path: "unknown"})
+	assert.Error(t, err)
+	assert.Equal(t, runtime.TopicEventResponse_RETRY, resp2.GetStatus())
+}
+
+func TestTopicRawPayload(t *testing.T) {
+	var handled *common.TopicEvent
+	server := getTestServer()
+	err := server.AddTopicEventHandler(&common.Subscription{PubsubName: "messages", Topic: "raw", RawPayload: true},
+		func(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
+			handled = e
+			return false, nil
+		})
+	assert.NoError(t, err)
+
+	resp, err := server.ListTopicSubscriptions(context.Background(), &empty.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"rawPayload": "true"}, resp.Subscriptions[0].Metadata)
+
+	_, err = server.OnTopicEvent(context.Background(), &runtime.TopicEventRequest{
+		Id:         "1",
+		Data:       []byte("hello"),
+		Topic:      "raw",
+		PubsubName: "messages",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello"), handled.Data)
+	assert.Equal(t, "aGVsbG8=", handled.DataBase64)
+	assert.Equal(t, "application/octet-stream", handled.DataContentType)
+}
+
+// topicTransport delivers the events of the conformance test suite to a gRPC server.
+type topicTransport struct {
+	*Server
+}
+
+func (s *topicTransport) Deliver(t *testing.T, pubsubName, topic, route string, ce *topictest.CloudEvent) {
+	resp, err := s.OnTopicEvent(context.Background(), &runtime.TopicEventRequest{
+		Id:              ce.ID,
+		Source:          ce.Source,
+		Type:            ce.Type,
+		SpecVersion:     "1.0",
+		DataContentType: ce.DataContentType,
+		Data:            ce.GRPCData(),
+		Topic:           topic,
+		PubsubName:      pubsubName,
+		Path:            route,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, runtime.TopicEventResponse_SUCCESS, resp.GetStatus())
+}
+
+func TestTopicEventConformance(t *testing.T) {
+	topictest.Run(t, &topicTransport{Server: getTestServer()})
+}
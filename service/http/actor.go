@@ -0,0 +1,109 @@
+package http
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/dapr/go-sdk/actor/runtime"
+	"github.com/dapr/go-sdk/service/internal/actorcallback"
+)
+
+const (
+	// ActorReentrancyIDHeader is the header Dapr identifies the reentrant call chain of an actor invocation with, the
+	// calls of the chain enter the actor while the chain holds it, and it's sent back as received so that they can be
+	// correlated.
+	ActorReentrancyIDHeader = "Dapr-Reentrancy-Id"
+)
+
+// actorErrorResponse is the JSON body of failed actor callbacks.
+type actorErrorResponse struct {
+	ErrorCode string `json:"errorCode"`
+	Message   string `json:"message"`
+}
+
+// actorRouter routes the actor callbacks of Dapr to the actor runtime, every callback answers exactly one status.
+type actorRouter struct {
+	runtime *runtime.ActorRunTime
+}
+
+func newActorRouter(rt *runtime.ActorRunTime) *actorRouter {
+	return &actorRouter{
+		runtime: rt,
+	}
+}
+
+// register registers the actor callback routes to @router. The reminder and timer routes have one more path segment
+// than the method route, so they are not shadowed by it.
+func (a *actorRouter) register(router *mux.Router) {
+	router.HandleFunc("/dapr/config", a.config).Methods(http.MethodGet)
+	router.HandleFunc("/actors/{actorType}/{actorId}/method/{methodName}", a.dispatch).Methods(http.MethodPut)
+	router.HandleFunc("/actors/{actorType}/{actorId}", a.dispatch).Methods(http.MethodDelete)
+	router.HandleFunc("/actors/{actorType}/{actorId}/method/remind/{reminderName}", a.dispatch).Methods(http.MethodPut)
+	router.HandleFunc("/actors/{actorType}/{actorId}/method/timer/{timerName}", a.dispatch).Methods(http.MethodPut)
+}
+
+func (a *actorRouter) config(w http.ResponseWriter, r *http.Request) {
+	data, err := a.runtime.GetJSONSerializedConfig()
+	if err != nil {
+		log.Printf("failed to serialize actor config, err = %s", err)
+		writeActorError(w, http.StatusInternalServerError, actorcallback.ErrorCodeUnknown, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// dispatch runs the actor callback @r on the actor runtime, the callback is parsed from the verb and the path of
+// @r the same way as the gRPC service does.
+func (a *actorRouter) dispatch(w http.ResponseWriter, r *http.Request) {
+	callback, ok := actorcallback.Parse(r.Method, r.URL.Path)
+	if !ok {
+		writeActorError(w, http.StatusNotFound, actorcallback.ErrorCodeUnknown, "not an actor callback: "+r.URL.Path)
+		return
+	}
+	reqData, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeActorError(w, http.StatusBadRequest, actorcallback.ErrorCodeUnknown, err.Error())
+		return
+	}
+	callback.Data = reqData
+	callback.ReentrancyID = r.Header.Get(ActorReentrancyIDHeader)
+	echoReentrancyID(w, r)
+	rspData, err := actorcallback.Dispatch(a.runtime, callback)
+	if err != nil {
+		a.fail(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(rspData)
+}
+
+// fail answers the actor callback @r which failed with @err, the status and error code are decided by the code of
+// @err, and its message is answered as is.
+func (a *actorRouter) fail(w http.ResponseWriter, r *http.Request, err error) {
+	status := actorcallback.StatusOf(err)
+	log.Printf("actor callback %s %s failed, code = %s, err = %s", r.Method, r.URL.Path, status.ErrorCode, err)
+	writeActorError(w, status.HTTPStatus, status.ErrorCode, err.Error())
+}
+
+func writeActorError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(&actorErrorResponse{
+		ErrorCode: code,
+		Message:   message,
+	})
+}
+
+// echoReentrancyID sends the reentrancy id of the actor callback @r back, it must be called before the status is
+// written.
+func echoReentrancyID(w http.ResponseWriter, r *http.Request) {
+	if id := r.Header.Get(ActorReentrancyIDHeader); id != "" {
+		w.Header().Set(ActorReentrancyIDHeader, id)
+	}
+}
@@ -0,0 +1,169 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dapr/go-sdk/actor"
+	"github.com/dapr/go-sdk/actor/api"
+	"github.com/dapr/go-sdk/actor/mock"
+	"github.com/dapr/go-sdk/service/internal/actortest"
+)
+
+func TestActorConfig(t *testing.T) {
+	s := newServer("", nil)
+	s.registerBaseHandler()
+	makeRequest(t, s, "/dapr/config", "", http.MethodGet, http.StatusOK)
+}
+
+func TestActorHandler(t *testing.T) {
+	reminderReqData, _ := json.Marshal(api.ActorReminderParams{
+		Data:    []byte("hello"),
+		DueTime: "5s",
+		Period:  "5s",
+	})
+
+	timerReqData, _ := json.Marshal(api.ActorTimerParam{
+		CallBack: "Invoke",
+		DueTime:  "5s",
+		Period:   "5s",
+		Data:     []byte(`"hello"`),
+	})
+
+	timerReqDataWithBadCallBackFunction, _ := json.Marshal(api.ActorTimerParam{
+		CallBack: "UnexistedFunc",
+		DueTime:  "5s",
+		Period:   "5s",
+		Data:     []byte(`"hello"`),
+	})
+	s := newActorTestServer()
+	s.registerBaseHandler()
+	// invoke actor API without target actor defined
+	makeRequest(t, s, "/actors/testActorType/testActorID/method/Invoke", "", http.MethodPut, http.StatusNotFound)
+	makeRequest(t, s, "/actors/testActorType/testActorID", "", http.MethodDelete, http.StatusNotFound)
+	makeRequest(t, s, "/actors/testActorType/testActorID/method/remind/testReminderName", string(reminderReqData), http.MethodPut, http.StatusNotFound)
+	makeRequest(t, s, "/actors/testActorType/testActorID/method/timer/testTimerName", string(timerReqData), http.MethodPut, http.StatusNotFound)
+
+	// register test actor factory
+	s.RegisterActorImplFactory(mock.ActorImplFactory)
+
+	// invoke actor API with internal error
+	makeRequest(t, s, "/actors/testActorType/testActorID/method/remind/testReminderName", `{
+"dueTime": "5s",
+"period": "5s",
+"data": "test data"`, http.MethodPut, http.StatusBadRequest)
+	makeRequest(t, s, "/actors/testActorType/testActorID/method/Invoke", "bad request param", http.MethodPut, http.StatusInternalServerError)
+	makeRequest(t, s, "/actors/testActorType/testActorID/method/timer/testTimerName", string(timerReqDataWithBadCallBackFunction), http.MethodPut, http.StatusNotFound)
+
+	// invoke actor API with success status
+	makeRequestWithExpectedBody(t, s, "/actors/testActorType/testActorID/method/Invoke", `"invoke request"`, http.MethodPut, http.StatusOK, []byte(`"invoke request"`))
+	makeRequest(t, s, "/actors/testActorType/testActorID/method/remind/testReminderName", string(reminderReqData), http.MethodPut, http.StatusOK)
+	makeRequest(t, s, "/actors/testActorType/testActorID/method/timer/testTimerName", string(timerReqData), http.MethodPut, http.StatusOK)
+	makeRequest(t, s, "/actors/testActorType/testActorID", "", http.MethodDelete, http.StatusOK)
+
+	// register not reminder callee actor factory
+	s.RegisterActorImplFactory(mock.NotReminderCalleeActorFactory)
+	// invoke call reminder to not reminder callee actor type
+	makeRequest(t, s, "/actors/testActorNotReminderCalleeType/testActorID/method/remind/testReminderName", string(reminderReqData), http.MethodPut, http.StatusNotImplemented)
+}
+
+// statusCounter counts the statuses written by the handler it wraps.
+type statusCounter struct {
+	http.ResponseWriter
+	count int
+}
+
+func (w *statusCounter) WriteHeader(status int) {
+	w.count++
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusCounter) Write(data []byte) (int, error) {
+	if w.count == 0 {
+		w.count++
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// actorTransport sends the actor callbacks of the conformance test suite to an HTTP server.
+type actorTransport struct {
+	*Server
+	server *httptest.Server
+	counts chan int
+}
+
+// expectedActorErrStatuses are the HTTP statuses of the error codes.
+var expectedActorErrStatuses = map[string]int{
+	"":                                  http.StatusOK,
+	"ERR_ACTOR_TYPE_NOT_FOUND":          http.StatusNotFound,
+	"ERR_ACTOR_ID_NOT_FOUND":            http.StatusNotFound,
+	"ERR_ACTOR_METHOD_NOT_FOUND":        http.StatusNotFound,
+	"ERR_ACTOR_REMINDER_PARAMS_INVALID": http.StatusBadRequest,
+	"ERR_ACTOR_TIMER_PARAMS_INVALID":    http.StatusBadRequest,
+	"ERR_ACTOR_REMINDER_UNDEFINED":      http.StatusNotImplemented,
+	"ERR_ACTOR_INVOKE_FAILED":           http.StatusInternalServerError,
+	"ERR_ACTOR_SERIALIZE_FAILED":        http.StatusInternalServerError,
+	"ERR_ACTOR_SERIALIZER_NOT_FOUND":    http.StatusInternalServerError,
+}
+
+func (a *actorTransport) Call(t *testing.T, req *actortest.Request) *actortest.Result {
+	r, err := http.NewRequest(req.Verb, a.server.URL+"/"+req.Path, bytes.NewReader(req.Data))
+	assert.NoError(t, err)
+	if req.ReentrancyID != "" {
+		r.Header.Set(ActorReentrancyIDHeader, req.ReentrancyID)
+	}
+	resp, err := a.server.Client().Do(r)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, <-a.counts)
+
+	result := &actortest.Result{
+		ReentrancyID: resp.Header.Get(ActorReentrancyIDHeader),
+	}
+	if resp.StatusCode == http.StatusOK {
+		result.Data = body
+		return result
+	}
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	rsp := &actorErrorResponse{}
+	assert.NoError(t, json.Unmarshal(body, rsp))
+	result.ErrorCode, result.Message = rsp.ErrorCode, rsp.Message
+	assert.Equal(t, expectedActorErrStatuses[rsp.ErrorCode], resp.StatusCode)
+	return result
+}
+
+// TestActorRouterConformance runs the conformance test suite of the actor callbacks through an HTTP server, which
+// must answer every callback with exactly one status.
+func TestActorRouterConformance(t *testing.T) {
+	s := newActorTestServer()
+	s.registerBaseHandler()
+	counts := make(chan int, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		counter := &statusCounter{ResponseWriter: w}
+		s.mux.ServeHTTP(counter, r)
+		counts <- counter.count
+	}))
+	defer server.Close()
+
+	actortest.Run(t, &actorTransport{Server: s, server: server, counts: counts})
+}
+
+func TestActorErrorMessage(t *testing.T) {
+	s := newActorTestServer()
+	s.RegisterActorImplFactory(func() actor.Server {
+		return &actortest.FailingActor{}
+	})
+	s.registerBaseHandler()
+
+	// the message carries the actor, the method and the cause of the failure
+	makeRequestWithExpectedBody(t, s, "/actors/failingActorType/testActorID/method/Fail", "", http.MethodPut, http.StatusInternalServerError,
+		[]byte(`{"errorCode":"ERR_ACTOR_INVOKE_FAILED","message":"actor invocation failed (type=failingActorType, id=testActorID, method=Fail): failed on purpose"}`+"\n"))
+}
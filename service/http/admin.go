@@ -0,0 +1,156 @@
+package http
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	actorErr "github.com/dapr/go-sdk/actor/error"
+	"github.com/dapr/go-sdk/actor/runtime"
+	"github.com/dapr/go-sdk/service/internal/actorcallback"
+)
+
+const (
+	// ActorAdminPathPrefix is the path prefix of the actor admin API.
+	ActorAdminPathPrefix = "/admin/actors"
+
+	actorAdminUnauthorized = "ERR_ACTOR_ADMIN_UNAUTHORIZED"
+	actorStateNotFound     = "ERR_ACTOR_STATE_NOT_FOUND"
+
+	// bearerPrefix is the scheme prefix of the Authorization header carrying the admin token
+	bearerPrefix = "Bearer "
+)
+
+// WithActorAdmin enables the actor admin API of the Server, which is disabled by default. Requests to the API must
+// carry @token as the bearer token of the Authorization header, the API stays disabled if @token is empty.
+// The API is for debugging actors, it lists the active actors, dumps the cached states, reads the persisted states
+// and deactivates actors:
+//
+//	GET    /admin/actors                                       active actor IDs per actor type
+//	GET    /admin/actors/{actorType}                           active actor IDs of the actor type
+//	GET    /admin/actors/{actorType}/{actorId}/state           cached states at the end of the last turn
+//	GET    /admin/actors/{actorType}/{actorId}/state/{name}    persisted state read through Dapr
+//	DELETE /admin/actors/{actorType}/{actorId}                 forced deactivation
+func WithActorAdmin(token string) Option {
+	return func(s *Server) {
+		if token == "" {
+			log.Printf("actor admin API is disabled, as its token is empty")
+			return
+		}
+		s.actorAdminToken = token
+	}
+}
+
+// actorAdminRouter serves the actor admin API, to the requests carrying its token only.
+type actorAdminRouter struct {
+	runtime *runtime.ActorRunTime
+	token   string
+}
+
+func newActorAdminRouter(rt *runtime.ActorRunTime, token string) *actorAdminRouter {
+	rt.EnableActorStateDump()
+	return &actorAdminRouter{
+		runtime: rt,
+		token:   token,
+	}
+}
+
+func (a *actorAdminRouter) register(router *mux.Router) {
+	adminRouter := router.PathPrefix(ActorAdminPathPrefix).Subrouter()
+	adminRouter.Use(a.authorize)
+	adminRouter.HandleFunc("", a.listActors).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/{actorType}", a.listActorsOfType).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/{actorType}/{actorId}/state", a.dumpState).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/{actorType}/{actorId}/state/{stateName}", a.getPersistedState).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/{actorType}/{actorId}", a.deactivate).Methods(http.MethodDelete)
+}
+
+// authorize rejects the requests whose bearer token is not the token of the admin API.
+func (a *actorAdminRouter) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("Authorization")
+		if !strings.HasPrefix(token, bearerPrefix) ||
+			subtle.ConstantTimeCompare([]byte(token[len(bearerPrefix):]), []byte(a.token)) != 1 {
+			writeActorError(w, http.StatusUnauthorized, actorAdminUnauthorized, "invalid actor admin token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (a *actorAdminRouter) listActors(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, a.runtime.ActiveActors())
+}
+
+func (a *actorAdminRouter) listActorsOfType(w http.ResponseWriter, r *http.Request) {
+	actorType := mux.Vars(r)["actorType"]
+	actorIDs, ok := a.runtime.ActiveActors()[actorType]
+	if !ok {
+		a.fail(w, r, actorErr.New(actorErr.ErrActorTypeNotFound, nil).WithActor(actorType, ""))
+		return
+	}
+	writeJSON(w, actorIDs)
+}
+
+func (a *actorAdminRouter) dumpState(w http.ResponseWriter, r *http.Request) {
+	varsMap := mux.Vars(r)
+	states, err := a.runtime.DumpActorState(varsMap["actorType"], varsMap["actorId"])
+	if err != nil {
+		a.fail(w, r, err)
+		return
+	}
+	writeJSON(w, states)
+}
+
+// getPersistedState answers the persisted state as it's stored, which is JSON unless the actor type is registered
+// with a state serializer of another format.
+func (a *actorAdminRouter) getPersistedState(w http.ResponseWriter, r *http.Request) {
+	varsMap := mux.Vars(r)
+	data, err := a.runtime.GetPersistedActorState(varsMap["actorType"], varsMap["actorId"], varsMap["stateName"])
+	if err != nil {
+		a.fail(w, r, err)
+		return
+	}
+	if len(data) == 0 {
+		writeActorError(w, http.StatusNotFound, actorStateNotFound, "state "+varsMap["stateName"]+" not found")
+		return
+	}
+	if json.Valid(data) {
+		w.Header().Set("Content-Type", "application/json")
+	} else {
+		w.Header().Set("Content-Type", "application/octet-stream")
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+func (a *actorAdminRouter) deactivate(w http.ResponseWriter, r *http.Request) {
+	varsMap := mux.Vars(r)
+	if err := a.runtime.Deactivate(varsMap["actorType"], varsMap["actorId"]); err != nil {
+		a.fail(w, r, err)
+		return
+	}
+	log.Printf("actor %s/%s is deactivated by actor admin API", varsMap["actorType"], varsMap["actorId"])
+	w.WriteHeader(http.StatusOK)
+}
+
+func (a *actorAdminRouter) fail(w http.ResponseWriter, r *http.Request, err error) {
+	status := actorcallback.StatusOf(err)
+	log.Printf("actor admin request %s %s failed, code = %s, err = %s", r.Method, r.URL.Path, status.ErrorCode, err)
+	writeActorError(w, status.HTTPStatus, status.ErrorCode, err.Error())
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		writeActorError(w, http.StatusInternalServerError, actorcallback.ErrorCodeUnknown, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
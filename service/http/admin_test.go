@@ -0,0 +1,97 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dapr/go-sdk/actor/mock"
+	"github.com/dapr/go-sdk/actor/runtime"
+	dapr "github.com/dapr/go-sdk/client"
+)
+
+// adminDaprClient answers the persisted actor states it holds.
+type adminDaprClient struct {
+	dapr.Client
+	states map[string][]byte
+}
+
+func (c *adminDaprClient) GetActorState(ctx context.Context, in *dapr.GetActorStateRequest) (*dapr.GetActorStateResponse, error) {
+	return &dapr.GetActorStateResponse{Data: c.states[in.ActorType+"/"+in.ActorID+"/"+in.KeyName]}, nil
+}
+
+func makeAdminRequest(s *Server, method, route, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, route, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rr := httptest.NewRecorder()
+	s.mux.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestActorAdmin(t *testing.T) {
+	daprClient := &adminDaprClient{states: map[string][]byte{
+		"testActorType/testActorID/json":   []byte(`{"name":"abc"}`),
+		"testActorType/testActorID/binary": {0xff, 0x00},
+	}}
+	s := newServer("", nil, WithActorRuntime(runtime.NewActorRuntimeWithClient(daprClient)), WithActorAdmin("secret"))
+	s.RegisterActorImplFactory(mock.ActorImplFactory)
+	s.registerBaseHandler()
+	makeRequest(t, s, "/actors/testActorType/testActorID/method/Invoke", `"hello"`, http.MethodPut, http.StatusOK)
+
+	tests := []struct {
+		name        string
+		method      string
+		route       string
+		token       string
+		status      int
+		body        string
+		contentType string
+	}{
+		{"no token", http.MethodGet, "/admin/actors", "", http.StatusUnauthorized, "", ""},
+		{"wrong token", http.MethodGet, "/admin/actors", "guess", http.StatusUnauthorized, "", ""},
+		{"list actors", http.MethodGet, "/admin/actors", "secret", http.StatusOK, `{"testActorType":["testActorID"]}`, "application/json"},
+		{"list actors of type", http.MethodGet, "/admin/actors/testActorType", "secret", http.StatusOK, `["testActorID"]`, "application/json"},
+		{"list actors of unknown type", http.MethodGet, "/admin/actors/unknownActorType", "secret", http.StatusNotFound, "", ""},
+		{"dump state", http.MethodGet, "/admin/actors/testActorType/testActorID/state", "secret", http.StatusOK, `[]`, "application/json"},
+		{"dump state of inactive actor", http.MethodGet, "/admin/actors/testActorType/inactiveActorID/state", "secret", http.StatusNotFound, "", ""},
+		{"read json state", http.MethodGet, "/admin/actors/testActorType/testActorID/state/json", "secret", http.StatusOK, `{"name":"abc"}`, "application/json"},
+		{"read binary state", http.MethodGet, "/admin/actors/testActorType/testActorID/state/binary", "secret", http.StatusOK, "\xff\x00", "application/octet-stream"},
+		{"read missing state", http.MethodGet, "/admin/actors/testActorType/testActorID/state/missing", "secret", http.StatusNotFound, "", ""},
+		{"deactivate", http.MethodDelete, "/admin/actors/testActorType/testActorID", "secret", http.StatusOK, "", ""},
+		{"deactivate again", http.MethodDelete, "/admin/actors/testActorType/testActorID", "secret", http.StatusNotFound, "", ""},
+		{"list actors after deactivation", http.MethodGet, "/admin/actors/testActorType", "secret", http.StatusOK, `[]`, "application/json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := makeAdminRequest(s, tt.method, tt.route, tt.token)
+			assert.Equal(t, tt.status, rr.Code)
+			if tt.body != "" {
+				assert.Equal(t, tt.body, rr.Body.String())
+				assert.Equal(t, tt.contentType, rr.Header().Get("Content-Type"))
+			}
+		})
+	}
+
+	// the token must be carried with the bearer scheme
+	for _, authorization := range []string{"secret", "Basic secret", "bearer secret", "Bearer  secret"} {
+		req := httptest.NewRequest(http.MethodGet, "/admin/actors", nil)
+		req.Header.Set("Authorization", authorization)
+		rr := httptest.NewRecorder()
+		s.mux.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code, authorization)
+	}
+}
+
+func TestActorAdminDisabled(t *testing.T) {
+	for _, s := range []*Server{newActorTestServer(), newServer("", nil, WithActorAdmin(""))} {
+		s.RegisterActorImplFactory(mock.ActorImplFactory)
+		s.registerBaseHandler()
+		rr := makeAdminRequest(s, http.MethodGet, "/admin/actors", "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	}
+}
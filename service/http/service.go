@@ -12,43 +12,69 @@ import (
 	"github.com/dapr/go-sdk/actor/runtime"
 
 	"github.com/dapr/go-sdk/service/common"
+	"github.com/dapr/go-sdk/service/internal/topics"
 )
 
+// Option is option function of Server.
+type Option func(s *Server)
+
+// WithActorRuntime sets the actor runtime of the Server to @rt, instead of a new runtime owned by the Server.
+func WithActorRuntime(rt *runtime.ActorRunTime) Option {
+	return func(s *Server) {
+		s.actorRuntime = rt
+	}
+}
+
 // NewService creates new Service.
-func NewService(address string) common.Service {
-	return newServer(address, nil)
+func NewService(address string, opts ...Option) common.Service {
+	return newServer(address, nil, opts...)
 }
 
 // NewServiceWithMux creates new Service with existing http mux.
-func NewServiceWithMux(address string, mux *mux.Router) common.Service {
-	return newServer(address, mux)
+func NewServiceWithMux(address string, mux *mux.Router, opts ...Option) common.Service {
+	return newServer(address, mux, opts...)
 }
 
-func newServer(address string, router *mux.Router) *Server {
+func newServer(address string, router *mux.Router, opts ...Option) *Server {
 	if router == nil {
 		router = mux.NewRouter()
 	}
-	return &Server{
+	s := &Server{
 		address: address,
 		httpServer: &http.Server{
 			Addr:    address,
 			Handler: router,
 		},
-		mux:                router,
-		topicSubscriptions: make([]*common.Subscription, 0),
+		mux:            router,
+		topicRegistrar: topics.NewRegistrar(),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.actorRuntime == nil {
+		s.actorRuntime = runtime.NewActorRuntime()
+	}
+	return s
 }
 
 // Server is the HTTP server wrapping mux many Dapr helpers.
 type Server struct {
-	address            string
-	mux                *mux.Router
-	httpServer         *http.Server
-	topicSubscriptions []*common.Subscription
+	address        string
+	mux            *mux.Router
+	httpServer     *http.Server
+	topicRegistrar *topics.Registrar
+	actorRuntime   *runtime.ActorRunTime
+	// actorAdminToken enables the actor admin API if it's not empty
+	actorAdminToken string
 }
 
 func (s *Server) RegisterActorImplFactory(f actor.Factory, opts ...config.Option) {
-	runtime.GetActorRuntimeInstance().RegisterActorFactory(f, opts...)
+	s.actorRuntime.RegisterActorFactory(f, opts...)
+}
+
+// RegisterActor registers @f as the factory of actor type @actorType to the actor runtime of the Server.
+func (s *Server) RegisterActor(actorType string, f actor.Factory, opts ...config.Option) error {
+	return s.actorRuntime.RegisterActor(actorType, f, opts...)
 }
 
 // Start starts the HTTP handler. Blocks while serving.
@@ -8,6 +8,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/dapr/go-sdk/actor/mock"
+	"github.com/dapr/go-sdk/actor/runtime"
 )
 
 func TestStoppingUnstartedService(t *testing.T) {
@@ -76,3 +79,39 @@ func testRequestWithResponseBody(t *testing.T, s *Server, r *http.Request, expec
 	assert.Equal(t, expectedStatusCode, rez.StatusCode)
 	assert.Equal(t, expectedBody, rspBody)
 }
+
+// newActorTestServer creates a Server whose actors use a fake Dapr client.
+func newActorTestServer() *Server {
+	return newServer("", nil, WithActorRuntime(runtime.NewActorRuntimeWithClient(mock.NewDaprClient())))
+}
+
+func TestActorRuntimeIsolation(t *testing.T) {
+	s1 := newActorTestServer()
+	s2 := newActorTestServer()
+	assert.NotSame(t, s1.actorRuntime, s2.actorRuntime)
+
+	s1.RegisterActorImplFactory(mock.ActorImplFactory)
+	s1.registerBaseHandler()
+	s2.registerBaseHandler()
+	makeRequest(t, s1, "/actors/testActorType/testActorID/method/Invoke", `"hello"`, http.MethodPut, http.StatusOK)
+	makeRequest(t, s2, "/actors/testActorType/testActorID/method/Invoke", `"hello"`, http.MethodPut, http.StatusNotFound)
+}
+
+func TestWithActorRuntime(t *testing.T) {
+	rt := runtime.NewActorRuntimeWithClient(mock.NewDaprClient())
+	rt.RegisterActorFactory(mock.ActorImplFactory)
+	s := newServer("", nil, WithActorRuntime(rt))
+	assert.Same(t, rt, s.actorRuntime)
+
+	s.registerBaseHandler()
+	makeRequest(t, s, "/actors/testActorType/testActorID/method/Invoke", `"hello"`, http.MethodPut, http.StatusOK)
+}
+
+func TestRegisterActor(t *testing.T) {
+	s := newActorTestServer()
+	assert.Nil(t, s.RegisterActor("otherActorType", mock.ActorImplFactory))
+	assert.Error(t, s.RegisterActor("otherActorType", mock.ActorImplFactory))
+
+	s.registerBaseHandler()
+	makeRequest(t, s, "/actors/otherActorType/testActorID/method/Invoke", `"hello"`, http.MethodPut, http.StatusOK)
+}
@@ -8,14 +8,10 @@ import (
 	"net/http"
 	"strings"
 
-	"github.com/gorilla/mux"
-
-	actorErr "github.com/dapr/go-sdk/actor/error"
-	"github.com/dapr/go-sdk/actor/runtime"
-
 	"github.com/pkg/errors"
 
 	"github.com/dapr/go-sdk/service/common"
+	"github.com/dapr/go-sdk/service/internal/topics"
 )
 
 const (
@@ -33,7 +29,7 @@ func (s *Server) registerBaseHandler() {
 	// register subscribe handler
 	f := func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
-		if err := json.NewEncoder(w).Encode(s.topicSubscriptions); err != nil {
+		if err := json.NewEncoder(w).Encode(s.topicRegistrar.Subscriptions()); err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
@@ -46,93 +42,11 @@ func (s *Server) registerBaseHandler() {
 	}
 	s.mux.HandleFunc("/healthz", fHealth).Methods(http.MethodGet)
 
-	// register actor config handler
-	fRegister := func(w http.ResponseWriter, r *http.Request) {
-		data, err := runtime.GetActorRuntimeInstance().GetJSONSerializedConfig()
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-		if _, err = w.Write(data); err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-		w.WriteHeader(http.StatusOK)
+	// register actor callback handlers
+	newActorRouter(s.actorRuntime).register(s.mux)
+	if s.actorAdminToken != "" {
+		newActorAdminRouter(s.actorRuntime, s.actorAdminToken).register(s.mux)
 	}
-	s.mux.HandleFunc("/dapr/config", fRegister).Methods(http.MethodGet)
-
-	// register actor method invoke handler
-	fInvoke := func(w http.ResponseWriter, r *http.Request) {
-		varsMap := mux.Vars(r)
-		actorType := varsMap["actorType"]
-		actorID := varsMap["actorId"]
-		methodName := varsMap["methodName"]
-		reqData, _ := ioutil.ReadAll(r.Body)
-		rspData, err := runtime.GetActorRuntimeInstance().InvokeActorMethod(actorType, actorID, methodName, reqData)
-		if err == actorErr.ErrActorTypeNotFound {
-			w.WriteHeader(http.StatusNotFound)
-			return
-		}
-		if err != actorErr.Success {
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write(rspData)
-	}
-	s.mux.HandleFunc("/actors/{actorType}/{actorId}/method/{methodName}", fInvoke).Methods(http.MethodPut)
-
-	// register deactivate actor handler
-	fDelete := func(w http.ResponseWriter, r *http.Request) {
-		varsMap := mux.Vars(r)
-		actorType := varsMap["actorType"]
-		actorID := varsMap["actorId"]
-		err := runtime.GetActorRuntimeInstance().Deactivate(actorType, actorID)
-		if err == actorErr.ErrActorTypeNotFound || err == actorErr.ErrActorIDNotFound {
-			w.WriteHeader(http.StatusNotFound)
-		}
-		if err != actorErr.Success {
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-		w.WriteHeader(http.StatusOK)
-	}
-	s.mux.HandleFunc("/actors/{actorType}/{actorId}", fDelete).Methods(http.MethodDelete)
-
-	// register actor reminder invoke handler
-	fReminder := func(w http.ResponseWriter, r *http.Request) {
-		varsMap := mux.Vars(r)
-		actorType := varsMap["actorType"]
-		actorID := varsMap["actorId"]
-		reminderName := varsMap["reminderName"]
-		reqData, _ := ioutil.ReadAll(r.Body)
-		err := runtime.GetActorRuntimeInstance().InvokeReminder(actorType, actorID, reminderName, reqData)
-		if err == actorErr.ErrActorTypeNotFound {
-			w.WriteHeader(http.StatusNotFound)
-		}
-		if err != actorErr.Success {
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-		w.WriteHeader(http.StatusOK)
-	}
-	s.mux.HandleFunc("/actors/{actorType}/{actorId}/method/remind/{reminderName}", fReminder).Methods(http.MethodPut)
-
-	// register actor timer invoke handler
-	fTimer := func(w http.ResponseWriter, r *http.Request) {
-		varsMap := mux.Vars(r)
-		actorType := varsMap["actorType"]
-		actorID := varsMap["actorId"]
-		timerName := varsMap["timerName"]
-		reqData, _ := ioutil.ReadAll(r.Body)
-		err := runtime.GetActorRuntimeInstance().InvokeTimer(actorType, actorID, timerName, reqData)
-		if err == actorErr.ErrActorTypeNotFound {
-			w.WriteHeader(http.StatusNotFound)
-		}
-		if err != actorErr.Success {
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-		w.WriteHeader(http.StatusOK)
-	}
-	s.mux.HandleFunc("/actors/{actorType}/{actorId}/method/timer/{timerName}", fTimer).Methods(http.MethodPut)
 }
 
 // AddTopicEventHandler appends provided event handler with it's name to the service.
@@ -157,7 +71,9 @@ func (s *Server) AddTopicEventHandler(sub *common.Subscription, fn func(ctx cont
 		sub.Route = fmt.Sprintf("/%s", sub.Route)
 	}
 
-	s.topicSubscriptions = append(s.topicSubscriptions, sub)
+	if err := s.topicRegistrar.AddSubscription(sub, fn); err != nil {
+		return err
+	}
 
 	s.mux.Handle(sub.Route, optionsHandler(http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
@@ -167,9 +83,14 @@ func (s *Server) AddTopicEventHandler(sub *common.Subscription, fn func(ctx cont
 				return
 			}
 
-			// deserialize the event
-			var in common.TopicEvent
-			if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			// deserialize the event, whose data is decoded the same way as the one of the gRPC service
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), PubSubHandlerDropStatusCode)
+				return
+			}
+			in, err := topics.DecodeHTTPEvent(body)
+			if err != nil {
 				http.Error(w, err.Error(), PubSubHandlerDropStatusCode)
 				return
 			}
@@ -181,8 +102,9 @@ func (s *Server) AddTopicEventHandler(sub *common.Subscription, fn func(ctx cont
 			w.Header().Add("Content-Type", "application/json")
 			w.WriteHeader(http.StatusOK)
 
-			// execute user handler
-			retry, err := fn(r.Context(), &in)
+			// execute user handler, which is the one of the raw payloads if the topic subscribes to them
+			handler, _ := s.topicRegistrar.Handler(sub.PubsubName, sub.Topic, sub.Route)
+			retry, err := handler(r.Context(), in)
 			if err == nil {
 				writeStatus(w, common.SubscriptionResponseStatusSuccess)
 				return
@@ -2,19 +2,16 @@ package http
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 	"testing"
 
-	"github.com/dapr/go-sdk/actor/api"
-	"github.com/dapr/go-sdk/actor/mock"
-
 	"github.com/stretchr/testify/assert"
 
 	"github.com/dapr/go-sdk/service/common"
+	"github.com/dapr/go-sdk/service/internal/topictest"
 )
 
 func testTopicFunc(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
@@ -85,67 +82,40 @@ func TestEventHandler(t *testing.T) {
 	makeEventRequest(t, s, "/errors", data, http.StatusOK)
 }
 
-func TestHealthCheck(t *testing.T) {
+func TestEventHandlerRoutes(t *testing.T) {
 	s := newServer("", nil)
+	handled := ""
+	handler := func(route string) func(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
+		return func(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
+			handled = route
+			return false, nil
+		}
+	}
+	subs := []*common.Subscription{
+		{PubsubName: "messages", Topic: "orders", Route: "/orders"},
+		{PubsubName: "messages", Topic: "orders", Route: "/orders/created", Match: `event.type == "order.created"`},
+	}
+	for _, sub := range subs {
+		assert.NoError(t, s.AddTopicEventHandler(sub, handler(sub.Route)))
+	}
 	s.registerBaseHandler()
-	makeRequest(t, s, "/healthz", "", http.MethodGet, http.StatusOK)
-}
 
-func TestActorConfig(t *testing.T) {
-	s := newServer("", nil)
-	s.registerBaseHandler()
-	makeRequest(t, s, "/dapr/config", "", http.MethodGet, http.StatusOK)
+	// the subscriptions are in the v2 format
+	makeRequestWithExpectedBody(t, s, "/dapr/subscribe", "", http.MethodGet, http.StatusOK,
+		[]byte(`[{"pubsubname":"messages","topic":"orders","routes":{"rules":[{"match":"event.type == \"order.created\"","path":"/orders/created"}],"default":"/orders"}}]`+"\n"))
+
+	data := `{"specversion":"1.0","type":"order.created","id":"1","datacontenttype":"application/json","data":{}}`
+	for _, route := range []string{"/orders", "/orders/created"} {
+		handled = ""
+		makeEventRequest(t, s, route, data, http.StatusOK)
+		assert.Equal(t, route, handled)
+	}
 }
 
-func TestActorHandler(t *testing.T) {
-	reminderReqData, _ := json.Marshal(api.ActorReminderParams{
-		Data:    []byte("hello"),
-		DueTime: "5s",
-		Period:  "5s",
-	})
-
-	timerReqData, _ := json.Marshal(api.ActorTimerParam{
-		CallBack: "Invoke",
-		DueTime:  "5s",
-		Period:   "5s",
-		Data:     []byte(`"hello"`),
-	})
-
-	timerReqDataWithBadCallBackFunction, _ := json.Marshal(api.ActorTimerParam{
-		CallBack: "UnexistedFunc",
-		DueTime:  "5s",
-		Period:   "5s",
-		Data:     []byte(`"hello"`),
-	})
+func TestHealthCheck(t *testing.T) {
 	s := newServer("", nil)
 	s.registerBaseHandler()
-	// invoke actor API without target actor defined
-	makeRequest(t, s, "/actors/testActorType/testActorID/method/Invoke", "", http.MethodPut, http.StatusNotFound)
-	makeRequest(t, s, "/actors/testActorType/testActorID", "", http.MethodDelete, http.StatusNotFound)
-	makeRequest(t, s, "/actors/testActorType/testActorID/method/remind/testReminderName", string(reminderReqData), http.MethodPut, http.StatusNotFound)
-	makeRequest(t, s, "/actors/testActorType/testActorID/method/timer/testTimerName", string(timerReqData), http.MethodPut, http.StatusNotFound)
-
-	// register test actor factory
-	s.RegisterActorImplFactory(mock.ActorImplFactory)
-
-	// invoke actor API with internal error
-	makeRequest(t, s, "/actors/testActorType/testActorID/method/remind/testReminderName", `{
-"dueTime": "5s",
-"period": "5s",
-"data": "test data"`, http.MethodPut, http.StatusInternalServerError)
-	makeRequest(t, s, "/actors/testActorType/testActorID/method/Invoke", "bad request param", http.MethodPut, http.StatusInternalServerError)
-	makeRequest(t, s, "/actors/testActorType/testActorID/method/timer/testTimerName", string(timerReqDataWithBadCallBackFunction), http.MethodPut, http.StatusInternalServerError)
-
-	// invoke actor API with success status
-	makeRequestWithExpectedBody(t, s, "/actors/testActorType/testActorID/method/Invoke", `"invoke request"`, http.MethodPut, http.StatusOK, []byte(`"invoke request"`))
-	makeRequest(t, s, "/actors/testActorType/testActorID/method/remind/testReminderName", string(reminderReqData), http.MethodPut, http.StatusOK)
-	makeRequest(t, s, "/actors/testActorType/testActorID/method/timer/testTimerName", string(timerReqData), http.MethodPut, http.StatusOK)
-	makeRequest(t, s, "/actors/testActorType/testActorID", "", http.MethodDelete, http.StatusOK)
-
-	// register not reminder callee actor factory
-	s.RegisterActorImplFactory(mock.NotReminderCalleeActorFactory)
-	// invoke call reminder to not reminder callee actor type
-	makeRequest(t, s, "/actors/testActorNotReminderCalleeType/testActorID/method/remind/testReminderName", string(reminderReqData), http.MethodPut, http.StatusInternalServerError)
+	makeRequest(t, s, "/healthz", "", http.MethodGet, http.StatusOK)
 }
 
 func makeRequest(t *testing.T, s *Server, route, data, method string, expectedStatusCode int) {
@@ -220,3 +190,43 @@ func TestRawPayloadDecode(t *testing.T) {
 	s.registerBaseHandler()
 	makeEventRequest(t, s, "/raw", rawData, http.StatusOK)
 }
+
+func TestRawPayloadSubscription(t *testing.T) {
+	var handled *common.TopicEvent
+	s := newServer("", nil)
+	err := s.AddTopicEventHandler(&common.Subscription{PubsubName: "messages", Topic: "raw", Route: "/raw", RawPayload: true},
+		func(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
+			handled = e
+			return false, nil
+		})
+	assert.NoError(t, err)
+	s.registerBaseHandler()
+
+	makeRequestWithExpectedBody(t, s, "/dapr/subscribe", "", http.MethodGet, http.StatusOK,
+		[]byte(`[{"pubsubname":"messages","topic":"raw","metadata":{"rawPayload":"true"},"routes":{"default":"/raw"}}]`+"\n"))
+
+	// Dapr wraps the raw payload into a CloudEvent, as data_base64
+	makeEventRequest(t, s, "/raw", `{"id":"1","specversion":"1.0","datacontenttype":"application/octet-stream","data_base64":"aGVsbG8="}`, http.StatusOK)
+	assert.Equal(t, []byte("hello"), handled.Data)
+	assert.Equal(t, "raw", handled.Topic)
+	assert.Equal(t, "messages", handled.PubsubName)
+}
+
+// topicTransport delivers the events of the conformance test suite to an HTTP server.
+type topicTransport struct {
+	*Server
+	registered bool
+}
+
+func (s *topicTransport) Deliver(t *testing.T, pubsubName, topic, route string, ce *topictest.CloudEvent) {
+	if !s.registered {
+		s.registerBaseHandler()
+		s.registered = true
+	}
+	makeRequestWithExpectedBody(t, s.Server, route, string(ce.JSON(pubsubName, topic)), http.MethodPost, http.StatusOK,
+		[]byte(`{"status":"SUCCESS"}`+"\n"))
+}
+
+func TestTopicEventConformance(t *testing.T) {
+	topictest.Run(t, &topicTransport{Server: newServer("", nil)})
+}
@@ -0,0 +1,131 @@
+// Package actorcallback parses and dispatches the actor callbacks of Dapr, it's shared by the HTTP and gRPC services
+// so that both transports route the callbacks to the actor runtime the same way.
+package actorcallback
+
+import (
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/dapr/go-sdk/actor/codec"
+	actorErr "github.com/dapr/go-sdk/actor/error"
+	"github.com/dapr/go-sdk/actor/runtime"
+)
+
+// Kind is the kind of an actor callback.
+type Kind string
+
+const (
+	Invoke     = Kind("invoke")
+	Deactivate = Kind("deactivate")
+	Reminder   = Kind("reminder")
+	Timer      = Kind("timer")
+)
+
+// Callback is an actor callback of Dapr, which is addressed by its verb and one of the paths:
+//
+//	PUT    actors/{actorType}/{actorId}/method/{methodName}
+//	PUT    actors/{actorType}/{actorId}/method/remind/{reminderName}
+//	PUT    actors/{actorType}/{actorId}/method/timer/{timerName}
+//	DELETE actors/{actorType}/{actorId}
+type Callback struct {
+	Kind      Kind
+	ActorType string
+	ActorID   string
+	// Name is the method, reminder or timer name, it's empty for deactivations.
+	Name string
+	// Codec is the name of the codec the arguments and the reply of a method are encoded by, which the caller declares
+	// in the method name, the codec the actor type is registered with is used if it's empty.
+	Codec string
+	// Data is the arguments of a method, or the JSON serialized api.ActorReminderParams or api.ActorTimerParam.
+	Data []byte
+	// ReentrancyID identifies the reentrant call chain of a method invocation, it's empty if reentrancy is disabled.
+	ReentrancyID string
+}
+
+// Parse parses the actor callback of @verb to @path, ok is false if it's not an actor callback. The leading slash
+// of @path is optional, as Dapr sends it in HTTP paths only.
+func Parse(verb, path string) (c *Callback, ok bool) {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(segments) < 3 || segments[0] != "actors" {
+		return nil, false
+	}
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, false
+		}
+	}
+	c = &Callback{
+		ActorType: segments[1],
+		ActorID:   segments[2],
+	}
+	switch {
+	case verb == http.MethodDelete && len(segments) == 3:
+		c.Kind = Deactivate
+	case verb == http.MethodPut && len(segments) == 5 && segments[3] == "method":
+		c.Kind = Invoke
+		c.Name, c.Codec = codec.SplitMethodCodec(segments[4])
+	case verb == http.MethodPut && len(segments) == 6 && segments[3] == "method" && segments[4] == "remind":
+		c.Kind, c.Name = Reminder, segments[5]
+	case verb == http.MethodPut && len(segments) == 6 && segments[3] == "method" && segments[4] == "timer":
+		c.Kind, c.Name = Timer, segments[5]
+	default:
+		return nil, false
+	}
+	return c, true
+}
+
+// Dispatch runs the actor callback @c on the actor runtime @rt, and returns the reply of a method invocation. The
+// reminder and timer params are decoded by the actor runtime, so that they are typed the same for all transports.
+func Dispatch(rt *runtime.ActorRunTime, c *Callback) ([]byte, error) {
+	switch c.Kind {
+	case Invoke:
+		return rt.InvokeActorMethodWithReentrancy(c.ReentrancyID, c.ActorType, c.ActorID, c.Name, c.Codec, c.Data)
+	case Deactivate:
+		return nil, rt.Deactivate(c.ActorType, c.ActorID)
+	case Reminder:
+		return nil, rt.InvokeReminder(c.ActorType, c.ActorID, c.Name, c.Data)
+	case Timer:
+		return nil, rt.InvokeTimer(c.ActorType, c.ActorID, c.Name, c.Data)
+	}
+	return nil, actorErr.New(actorErr.ErrActorMethodNoFound, nil).WithActor(c.ActorType, c.ActorID)
+}
+
+// ErrorCodeUnknown is the error code of the errors whose actor error code has no status, and of the requests
+// which are not actor callbacks.
+const ErrorCodeUnknown = "ERR_ACTOR_UNKNOWN"
+
+// Status is the status an actor callback failed with an actor error is answered with, by each transport.
+type Status struct {
+	// ErrorCode is the error code Dapr is answered with, it's the same for all transports.
+	ErrorCode  string
+	HTTPStatus int
+	GRPCCode   codes.Code
+}
+
+var statuses = map[actorErr.ActorErr]Status{
+	actorErr.ErrActorTypeNotFound:          {"ERR_ACTOR_TYPE_NOT_FOUND", http.StatusNotFound, codes.NotFound},
+	actorErr.ErrActorIDNotFound:            {"ERR_ACTOR_ID_NOT_FOUND", http.StatusNotFound, codes.NotFound},
+	actorErr.ErrActorMethodNoFound:         {"ERR_ACTOR_METHOD_NOT_FOUND", http.StatusNotFound, codes.NotFound},
+	actorErr.ErrRemindersParamsInvalid:     {"ERR_ACTOR_REMINDER_PARAMS_INVALID", http.StatusBadRequest, codes.InvalidArgument},
+	actorErr.ErrTimerParamsInvalid:         {"ERR_ACTOR_TIMER_PARAMS_INVALID", http.StatusBadRequest, codes.InvalidArgument},
+	actorErr.ErrReminderFuncUndefined:      {"ERR_ACTOR_REMINDER_UNDEFINED", http.StatusNotImplemented, codes.Unimplemented},
+	actorErr.ErrActorInvokeFailed:          {"ERR_ACTOR_INVOKE_FAILED", http.StatusInternalServerError, codes.Internal},
+	actorErr.ErrActorScheduleDone:          {"ERR_ACTOR_INVOKE_FAILED", http.StatusInternalServerError, codes.Internal},
+	actorErr.ErrActorMethodSerializeFailed: {"ERR_ACTOR_SERIALIZE_FAILED", http.StatusInternalServerError, codes.Internal},
+	actorErr.ErrActorSerializeNoFound:      {"ERR_ACTOR_SERIALIZER_NOT_FOUND", http.StatusInternalServerError, codes.Internal},
+	actorErr.ErrActorFactoryNotSet:         {"ERR_ACTOR_FACTORY_NOT_SET", http.StatusInternalServerError, codes.Internal},
+	actorErr.ErrActorServerInvalid:         {"ERR_ACTOR_SERVER_INVALID", http.StatusInternalServerError, codes.Internal},
+	actorErr.ErrSaveStateFailed:            {"ERR_ACTOR_SAVE_STATE_FAILED", http.StatusInternalServerError, codes.Internal},
+	actorErr.ErrStateProviderNotFound:      {"ERR_ACTOR_STATE_PROVIDER_NOT_FOUND", http.StatusInternalServerError, codes.Internal},
+	actorErr.ErrDaprClientNotAvailable:     {"ERR_ACTOR_DAPR_CLIENT_NOT_AVAILABLE", http.StatusServiceUnavailable, codes.Unavailable},
+}
+
+// StatusOf returns the status of the actor error @err, errors without an actor error code are invocation failures.
+func StatusOf(err error) Status {
+	if status, ok := statuses[actorErr.CodeOf(err)]; ok {
+		return status
+	}
+	return Status{ErrorCodeUnknown, http.StatusInternalServerError, codes.Internal}
+}
@@ -0,0 +1,49 @@
+package actorcallback
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	actorErr "github.com/dapr/go-sdk/actor/error"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		verb     string
+		path     string
+		expected *Callback
+	}{
+		{"invoke", http.MethodPut, "actors/testActorType/testActorID/method/Invoke", &Callback{Kind: Invoke, ActorType: "testActorType", ActorID: "testActorID", Name: "Invoke"}},
+		{"invoke with leading slash", http.MethodPut, "/actors/testActorType/testActorID/method/Invoke", &Callback{Kind: Invoke, ActorType: "testActorType", ActorID: "testActorID", Name: "Invoke"}},
+		{"invoke with codec", http.MethodPut, "actors/testActorType/testActorID/method/Invoke.msgpack", &Callback{Kind: Invoke, ActorType: "testActorType", ActorID: "testActorID", Name: "Invoke", Codec: "msgpack"}},
+		{"invoke method named remind", http.MethodPut, "actors/testActorType/testActorID/method/remind", &Callback{Kind: Invoke, ActorType: "testActorType", ActorID: "testActorID", Name: "remind"}},
+		{"reminder", http.MethodPut, "actors/testActorType/testActorID/method/remind/testReminder", &Callback{Kind: Reminder, ActorType: "testActorType", ActorID: "testActorID", Name: "testReminder"}},
+		{"timer", http.MethodPut, "actors/testActorType/testActorID/method/timer/testTimer", &Callback{Kind: Timer, ActorType: "testActorType", ActorID: "testActorID", Name: "testTimer"}},
+		{"deactivate", http.MethodDelete, "actors/testActorType/testActorID", &Callback{Kind: Deactivate, ActorType: "testActorType", ActorID: "testActorID"}},
+		{"service invocation", http.MethodPut, "echo", nil},
+		{"wrong verb", http.MethodPost, "actors/testActorType/testActorID/method/Invoke", nil},
+		{"deactivate with put", http.MethodPut, "actors/testActorType/testActorID", nil},
+		{"empty segment", http.MethodPut, "actors/testActorType//method/Invoke", nil},
+		{"unknown callback", http.MethodPut, "actors/testActorType/testActorID/method/other/testTimer", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, ok := Parse(tt.verb, tt.path)
+			assert.Equal(t, tt.expected != nil, ok)
+			assert.Equal(t, tt.expected, c)
+		})
+	}
+}
+
+func TestStatusOf(t *testing.T) {
+	assert.Equal(t, Status{"ERR_ACTOR_TYPE_NOT_FOUND", http.StatusNotFound, codes.NotFound},
+		StatusOf(actorErr.New(actorErr.ErrActorTypeNotFound, nil).WithActor("testActorType", "testActorID")))
+	// errors which are not actor errors are invocation failures
+	assert.Equal(t, Status{"ERR_ACTOR_INVOKE_FAILED", http.StatusInternalServerError, codes.Internal}, StatusOf(errors.New("failed")))
+	assert.Equal(t, Status{ErrorCodeUnknown, http.StatusInternalServerError, codes.Internal}, StatusOf(actorErr.Success))
+}
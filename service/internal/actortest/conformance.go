@@ -0,0 +1,126 @@
+// Package actortest is the conformance test suite of the actor callbacks, which the HTTP and gRPC services both run,
+// so that the transports can't diverge.
+package actortest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dapr/go-sdk/actor"
+	"github.com/dapr/go-sdk/actor/api"
+	"github.com/dapr/go-sdk/actor/config"
+	"github.com/dapr/go-sdk/actor/mock"
+)
+
+// Request is an actor callback sent by Dapr.
+type Request struct {
+	Verb string
+	// Path is the path of the callback without the leading slash, as actors/{actorType}/{actorId}/method/{name}.
+	Path         string
+	Data         []byte
+	ReentrancyID string
+}
+
+// Result is the answer of a service to an actor callback.
+type Result struct {
+	// ErrorCode is empty if the callback succeeded.
+	ErrorCode string
+	Message   string
+	Data      []byte
+	// ReentrancyID is the one sent back.
+	ReentrancyID string
+}
+
+// Transport is a service under test, whose actor runtime has no actor type registered yet.
+type Transport interface {
+	// RegisterActorImplFactory registers the actor factory @f to the service.
+	RegisterActorImplFactory(f actor.Factory, opts ...config.Option)
+	// Call sends the actor callback @req to the service and returns its answer, the transport asserts that the
+	// status it answers is the one of the error code.
+	Call(t *testing.T, req *Request) *Result
+}
+
+// FailingActor is an actor whose Fail method always fails.
+type FailingActor struct {
+	actor.ServerImplBase
+}
+
+func (a *FailingActor) Type() string {
+	return "failingActorType"
+}
+
+func (a *FailingActor) Fail(context.Context) error {
+	return errors.New("failed on purpose")
+}
+
+// Run runs the conformance test suite against @transport.
+func Run(t *testing.T, transport Transport) {
+	reminderParam, _ := json.Marshal(api.ActorReminderParams{
+		Data:    []byte(`"hello"`),
+		DueTime: "5s",
+		Period:  "5s",
+	})
+	timerParam := func(callback string) []byte {
+		param, _ := json.Marshal(api.ActorTimerParam{
+			CallBack: callback,
+			DueTime:  "5s",
+			Period:   "5s",
+			Data:     []byte(`"hello"`),
+		})
+		return param
+	}
+
+	transport.RegisterActorImplFactory(mock.ActorImplFactory)
+	transport.RegisterActorImplFactory(mock.NotReminderCalleeActorFactory)
+	transport.RegisterActorImplFactory(func() actor.Server {
+		return &FailingActor{}
+	})
+
+	tests := []struct {
+		name          string
+		req           Request
+		expectedError string
+		expectedData  string
+	}{
+		{"invoke", Request{http.MethodPut, "actors/testActorType/testActorID/method/Invoke", []byte(`"hello"`), ""}, "", `"hello"`},
+		{"invoke reentrant", Request{http.MethodPut, "actors/testActorType/testActorID/method/Invoke", []byte(`"hello"`), "reentrancy-1"}, "", `"hello"`},
+		{"invoke with codec", Request{http.MethodPut, "actors/testActorType/testActorID/method/Invoke.yaml", []byte("hello\n"), ""}, "", "hello\n"},
+		{"invoke unknown codec", Request{http.MethodPut, "actors/testActorType/testActorID/method/Invoke.unknown", []byte(`"hello"`), ""}, "ERR_ACTOR_SERIALIZER_NOT_FOUND", ""},
+		{"invoke unknown type", Request{http.MethodPut, "actors/unknownActorType/testActorID/method/Invoke", []byte(`"hello"`), ""}, "ERR_ACTOR_TYPE_NOT_FOUND", ""},
+		{"invoke unknown method", Request{http.MethodPut, "actors/testActorType/testActorID/method/Unknown", []byte(`"hello"`), ""}, "ERR_ACTOR_METHOD_NOT_FOUND", ""},
+		{"invoke bad param", Request{http.MethodPut, "actors/testActorType/testActorID/method/Invoke", []byte("bad param"), ""}, "ERR_ACTOR_SERIALIZE_FAILED", ""},
+		{"invoke failed", Request{http.MethodPut, "actors/failingActorType/testActorID/method/Fail", nil, ""}, "ERR_ACTOR_INVOKE_FAILED", ""},
+		{"reminder", Request{http.MethodPut, "actors/testActorType/testActorID/method/remind/testReminder", reminderParam, ""}, "", ""},
+		{"reminder unknown type", Request{http.MethodPut, "actors/unknownActorType/testActorID/method/remind/testReminder", reminderParam, ""}, "ERR_ACTOR_TYPE_NOT_FOUND", ""},
+		{"reminder bad param", Request{http.MethodPut, "actors/testActorType/testActorID/method/remind/testReminder", []byte("{"), ""}, "ERR_ACTOR_REMINDER_PARAMS_INVALID", ""},
+		{"reminder undefined", Request{http.MethodPut, "actors/testActorNotReminderCalleeType/testActorID/method/remind/testReminder", reminderParam, ""}, "ERR_ACTOR_REMINDER_UNDEFINED", ""},
+		{"timer", Request{http.MethodPut, "actors/testActorType/testActorID/method/timer/testTimer", timerParam("Invoke"), "reentrancy-2"}, "", ""},
+		{"timer unknown type", Request{http.MethodPut, "actors/unknownActorType/testActorID/method/timer/testTimer", timerParam("Invoke"), ""}, "ERR_ACTOR_TYPE_NOT_FOUND", ""},
+		{"timer bad param", Request{http.MethodPut, "actors/testActorType/testActorID/method/timer/testTimer", []byte("{"), ""}, "ERR_ACTOR_TIMER_PARAMS_INVALID", ""},
+		{"timer unknown callback", Request{http.MethodPut, "actors/testActorType/testActorID/method/timer/testTimer", timerParam("Unknown"), ""}, "ERR_ACTOR_METHOD_NOT_FOUND", ""},
+		{"timer failed", Request{http.MethodPut, "actors/failingActorType/testActorID/method/timer/testTimer", timerParam("Fail"), ""}, "ERR_ACTOR_INVOKE_FAILED", ""},
+		{"deactivate", Request{http.MethodDelete, "actors/testActorType/testActorID", nil, ""}, "", ""},
+		{"deactivate inactive", Request{http.MethodDelete, "actors/testActorType/testActorID", nil, ""}, "ERR_ACTOR_ID_NOT_FOUND", ""},
+		{"deactivate unknown type", Request{http.MethodDelete, "actors/unknownActorType/testActorID", nil, ""}, "ERR_ACTOR_TYPE_NOT_FOUND", ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			result := transport.Call(t, &tt.req)
+			assert.Equal(t, tt.expectedError, result.ErrorCode)
+			assert.Equal(t, tt.req.ReentrancyID, result.ReentrancyID)
+			if tt.expectedError != "" {
+				assert.NotEmpty(t, result.Message)
+				return
+			}
+			if tt.expectedData != "" {
+				assert.Equal(t, tt.expectedData, string(result.Data))
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Package contenttype classifies the content types of the topic event data the way Dapr does, so that the services
+// and the event decoding agree on how the data is carried.
+package contenttype
+
+import "strings"
+
+// media returns the lower case media type of @contentType, without its parameters.
+func media(contentType string) string {
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
+
+// IsJSON reports whether @contentType is JSON, including the structured syntaxes with the +json suffix.
+func IsJSON(contentType string) bool {
+	m := media(contentType)
+	return m == "application/json" || m == "text/json" || strings.HasSuffix(m, "+json")
+}
+
+// IsString reports whether the data of @contentType is carried as a string, which Dapr does for text and XML.
+func IsString(contentType string) bool {
+	m := media(contentType)
+	return strings.HasPrefix(m, "text/") && m != "text/json" || strings.HasSuffix(m, "/xml") || strings.HasSuffix(m, "+xml")
+}
+
+// IsProtobuf reports whether @contentType is serialized protocol buffers.
+func IsProtobuf(contentType string) bool {
+	switch media(contentType) {
+	case "application/protobuf", "application/x-protobuf", "application/vnd.google.protobuf":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package topics
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	"github.com/dapr/go-sdk/service/common"
+	"github.com/dapr/go-sdk/service/internal/contenttype"
+)
+
+// DecodeHTTPEvent decodes the CloudEvent @body, which Dapr sends to HTTP services, into a topic event whose data is
+// the same as the one of the event Dapr would send to gRPC services.
+func DecodeHTTPEvent(body []byte) (*common.TopicEvent, error) {
+	e := &common.TopicEvent{}
+	if err := json.Unmarshal(body, e); err != nil {
+		return nil, err
+	}
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, err
+	}
+	var rawData []byte
+	switch {
+	case e.DataBase64 != "":
+		decoded, err := base64.StdEncoding.DecodeString(e.DataBase64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode data_base64 of event %s", e.ID)
+		}
+		rawData = decoded
+	case e.Data == nil:
+	default:
+		// Dapr sends the strings of non JSON content as is, and the JSON content as it's published
+		if s, ok := e.Data.(string); ok && !contenttype.IsJSON(e.DataContentType) {
+			rawData = []byte(s)
+		} else {
+			rawData = envelope.Data
+		}
+	}
+	setData(e, rawData)
+	return e, nil
+}
+
+// NewGRPCEvent returns the topic event of @in, which Dapr sends to gRPC services.
+func NewGRPCEvent(in *pb.TopicEventRequest) *common.TopicEvent {
+	e := &common.TopicEvent{
+		ID:              in.Id,
+		Source:          in.Source,
+		Type:            in.Type,
+		SpecVersion:     in.SpecVersion,
+		DataContentType: in.DataContentType,
+		Topic:           in.Topic,
+		PubsubName:      in.PubsubName,
+	}
+	setData(e, in.Data)
+	return e
+}
+
+// setData sets the content @rawData of the event @e, and the Data and DataBase64 decoded from it by the content type.
+// Only JSON content is decoded, the content without a content type is text as Dapr sends it, even if it's valid JSON,
+// so that a payload such as 123 is the string handlers published rather than a number.
+func setData(e *common.TopicEvent, rawData []byte) {
+	e.RawData, e.Data, e.DataBase64 = rawData, nil, ""
+	if rawData == nil {
+		return
+	}
+	ct := e.DataContentType
+	if contenttype.IsJSON(ct) {
+		var v interface{}
+		if err := json.Unmarshal(rawData, &v); err == nil {
+			e.Data = v
+			return
+		}
+	}
+	if ct == "" || contenttype.IsString(ct) {
+		e.Data = string(rawData)
+		return
+	}
+	e.Data = rawData
+	e.DataBase64 = base64.StdEncoding.EncodeToString(rawData)
+}
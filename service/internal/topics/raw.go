@@ -0,0 +1,64 @@
+package topics
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/dapr/go-sdk/service/common"
+)
+
+const (
+	// RawPayloadMetadataKey is the subscription metadata key telling Dapr that the events of a topic are published
+	// as raw payloads, which Dapr wraps into CloudEvents with the payload as data_base64.
+	RawPayloadMetadataKey = "rawPayload"
+
+	rawPayloadContentType = "application/octet-stream"
+	rawPayloadSpecVersion = "1.0"
+)
+
+// isRawPayload reports whether the subscription metadata @metadata subscribes to raw payloads.
+func isRawPayload(metadata map[string]string) bool {
+	raw, err := strconv.ParseBool(metadata[RawPayloadMetadataKey])
+	return err == nil && raw
+}
+
+// withRawPayload returns a copy of the subscription metadata @metadata subscribing to raw payloads.
+func withRawPayload(metadata map[string]string) map[string]string {
+	copied := make(map[string]string, len(metadata)+1)
+	for k, v := range metadata {
+		copied[k] = v
+	}
+	copied[RawPayloadMetadataKey] = "true"
+	return copied
+}
+
+// rawPayloadHandler hands @fn the events of topic @topic with the raw payload as []byte Data, whatever the content
+// type, and the attributes missing from the CloudEvent Dapr wrapped the payload into synthesized.
+func rawPayloadHandler(pubsubName, topic string, fn Handler) Handler {
+	return func(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
+		payload := e.RawData
+		if payload == nil && e.DataBase64 != "" {
+			if payload, err = base64.StdEncoding.DecodeString(e.DataBase64); err != nil {
+				return false, errors.Wrapf(err, "failed to decode raw payload of topic %s/%s", pubsubName, topic)
+			}
+		}
+		e.RawData, e.Data = payload, payload
+		e.DataBase64 = base64.StdEncoding.EncodeToString(payload)
+		if e.DataContentType == "" {
+			e.DataContentType = rawPayloadContentType
+		}
+		if e.SpecVersion == "" {
+			e.SpecVersion = rawPayloadSpecVersion
+		}
+		if e.PubsubName == "" {
+			e.PubsubName = pubsubName
+		}
+		if e.Topic == "" {
+			e.Topic = topic
+		}
+		return fn(ctx, e)
+	}
+}
@@ -0,0 +1,141 @@
+// Package topics keeps the topic subscriptions of a service and the handlers of their routes, it's shared by the
+// HTTP and gRPC services so that both declare the same subscriptions to Dapr and dispatch events the same way.
+package topics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/dapr/go-sdk/service/common"
+)
+
+// Handler is the handler of the topic events delivered to a route.
+type Handler func(ctx context.Context, e *common.TopicEvent) (retry bool, err error)
+
+// Subscription is the subscription of a topic in the v2 format, whose routes are matched by Dapr.
+type Subscription struct {
+	PubsubName string            `json:"pubsubname"`
+	Topic      string            `json:"topic"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	Routes     *Routes           `json:"routes"`
+}
+
+// Routes are the routing rules of a topic, the events matched by no rule are delivered to the default path.
+type Routes struct {
+	Rules   []*Rule `json:"rules,omitempty"`
+	Default string  `json:"default,omitempty"`
+}
+
+// Rule delivers the events matching the CEL expression Match to Path.
+type Rule struct {
+	Match string `json:"match"`
+	Path  string `json:"path"`
+	// priority orders the rules, which Dapr evaluates in order
+	priority int
+}
+
+// registration is the subscription of a topic and the handlers of its paths.
+type registration struct {
+	subscription *Subscription
+	// handlers are keyed by path
+	handlers map[string]Handler
+	// hasDefault is true once the default route is added, which may be the empty path
+	hasDefault bool
+}
+
+// Registrar keeps the subscriptions in the order their topics are subscribed, it's not safe for concurrent use, as
+// handlers are added before the service starts.
+type Registrar struct {
+	registrations map[string]*registration
+	keys          []string
+}
+
+// NewRegistrar creates a Registrar without subscriptions.
+func NewRegistrar() *Registrar {
+	return &Registrar{
+		registrations: make(map[string]*registration),
+	}
+}
+
+func keyOf(pubsubName, topic string) string {
+	return fmt.Sprintf("%s-%s", pubsubName, topic)
+}
+
+// AddSubscription adds the route of @sub with its handler @fn. A route without Match is the default route of the
+// topic, and a route with Match is a rule, ordered by Priority. It fails if the default route or the path of the rule
+// is added already. The metadata of the first subscription of a topic is the metadata of the topic, the topic
+// subscribes to raw payloads if any of its subscriptions does.
+func (r *Registrar) AddSubscription(sub *common.Subscription, fn Handler) error {
+	key := keyOf(sub.PubsubName, sub.Topic)
+	reg, ok := r.registrations[key]
+	if !ok {
+		reg = &registration{
+			subscription: &Subscription{
+				PubsubName: sub.PubsubName,
+				Topic:      sub.Topic,
+				Metadata:   sub.Metadata,
+				Routes:     &Routes{},
+			},
+			handlers: make(map[string]Handler),
+		}
+	}
+	if _, ok := reg.handlers[sub.Route]; ok {
+		return errors.Errorf("route %s of topic %s/%s is subscribed already", sub.Route, sub.PubsubName, sub.Topic)
+	}
+	routes := reg.subscription.Routes
+	if sub.Match == "" {
+		if reg.hasDefault {
+			return errors.Errorf("default route of topic %s/%s is subscribed already", sub.PubsubName, sub.Topic)
+		}
+		routes.Default = sub.Route
+		reg.hasDefault = true
+	} else {
+		routes.Rules = append(routes.Rules, &Rule{
+			Match:    sub.Match,
+			Path:     sub.Route,
+			priority: sub.Priority,
+		})
+		sort.SliceStable(routes.Rules, func(i, j int) bool {
+			return routes.Rules[i].priority < routes.Rules[j].priority
+		})
+	}
+	if sub.RawPayload && !isRawPayload(reg.subscription.Metadata) {
+		reg.subscription.Metadata = withRawPayload(reg.subscription.Metadata)
+	}
+	reg.handlers[sub.Route] = fn
+	if !ok {
+		r.registrations[key] = reg
+		r.keys = append(r.keys, key)
+	}
+	return nil
+}
+
+// Subscriptions returns the subscriptions in the order their topics are subscribed.
+func (r *Registrar) Subscriptions() []*Subscription {
+	subs := make([]*Subscription, 0, len(r.keys))
+	for _, key := range r.keys {
+		subs = append(subs, r.registrations[key].subscription)
+	}
+	return subs
+}
+
+// Handler returns the handler of the events of topic @topic delivered to @path, ok is false if the path is not
+// subscribed. Events without a path, which Dapr sends if it doesn't support routing, are handled by the default
+// route. The handlers of topics subscribing to raw payloads are handed the unwrapped payloads.
+func (r *Registrar) Handler(pubsubName, topic, path string) (fn Handler, ok bool) {
+	reg, ok := r.registrations[keyOf(pubsubName, topic)]
+	if !ok {
+		return nil, false
+	}
+	if path == "" && reg.hasDefault {
+		path = reg.subscription.Routes.Default
+	}
+	fn, ok = reg.handlers[path]
+	if ok && isRawPayload(reg.subscription.Metadata) {
+		fn = rawPayloadHandler(pubsubName, topic, fn)
+	}
+	return fn, ok
+}
@@ -0,0 +1,116 @@
+package topics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dapr/go-sdk/service/common"
+)
+
+func TestRegistrar(t *testing.T) {
+	handled := ""
+	handler := func(path string) Handler {
+		return func(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
+			handled = path
+			return false, nil
+		}
+	}
+	r := NewRegistrar()
+	subs := []*common.Subscription{
+		{PubsubName: "messages", Topic: "orders", Route: "/orders", Metadata: map[string]string{"key": "value"}},
+		{PubsubName: "messages", Topic: "orders", Route: "/orders/cancelled", Match: `event.type == "order.cancelled"`, Priority: 2},
+		{PubsubName: "messages", Topic: "orders", Route: "/orders/created", Match: `event.type == "order.created"`, Priority: 1},
+		{PubsubName: "messages", Topic: "payments", Route: "/payments"},
+	}
+	for _, sub := range subs {
+		assert.NoError(t, r.AddSubscription(sub, handler(sub.Route)))
+	}
+
+	assert.Equal(t, []*Subscription{
+		{
+			PubsubName: "messages",
+			Topic:      "orders",
+			Metadata:   map[string]string{"key": "value"},
+			Routes: &Routes{
+				Rules: []*Rule{
+					{Match: `event.type == "order.created"`, Path: "/orders/created", priority: 1},
+					{Match: `event.type == "order.cancelled"`, Path: "/orders/cancelled", priority: 2},
+				},
+				Default: "/orders",
+			},
+		},
+		{
+			PubsubName: "messages",
+			Topic:      "payments",
+			Routes:     &Routes{Default: "/payments"},
+		},
+	}, r.Subscriptions())
+
+	// the default route and the paths can't be subscribed twice
+	assert.Error(t, r.AddSubscription(&common.Subscription{PubsubName: "messages", Topic: "orders", Route: "/orders/other"}, handler("")))
+	assert.Error(t, r.AddSubscription(&common.Subscription{PubsubName: "messages", Topic: "orders", Route: "/orders/created", Match: "true"}, handler("")))
+
+	tests := []struct {
+		name     string
+		topic    string
+		path     string
+		expected string
+	}{
+		{"rule", "orders", "/orders/created", "/orders/created"},
+		{"default", "orders", "/orders", "/orders"},
+		{"without path", "orders", "", "/orders"},
+		{"unknown path", "orders", "/orders/unknown", ""},
+		{"unknown topic", "unknown", "/orders", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled = ""
+			fn, ok := r.Handler("messages", tt.topic, tt.path)
+			assert.Equal(t, tt.expected != "", ok)
+			if ok {
+				_, _ = fn(context.Background(), &common.TopicEvent{})
+			}
+			assert.Equal(t, tt.expected, handled)
+		})
+	}
+}
+
+func TestRawPayload(t *testing.T) {
+	var handled *common.TopicEvent
+	handler := func(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
+		handled = e
+		return false, nil
+	}
+	r := NewRegistrar()
+	metadata := map[string]string{"key": "value"}
+	assert.NoError(t, r.AddSubscription(&common.Subscription{PubsubName: "messages", Topic: "raw", Route: "/raw", Metadata: metadata}, handler))
+	assert.NoError(t, r.AddSubscription(&common.Subscription{PubsubName: "messages", Topic: "raw", Route: "/raw/rule", Match: "true", RawPayload: true}, handler))
+	assert.Equal(t, map[string]string{"key": "value", RawPayloadMetadataKey: "true"}, r.Subscriptions()[0].Metadata)
+	assert.Equal(t, map[string]string{"key": "value"}, metadata)
+
+	expected := &common.TopicEvent{
+		ID:              "1",
+		SpecVersion:     "1.0",
+		DataContentType: "application/octet-stream",
+		Data:            []byte("hello"),
+		RawData:         []byte("hello"),
+		DataBase64:      "aGVsbG8=",
+		Topic:           "raw",
+		PubsubName:      "messages",
+	}
+	fn, ok := r.Handler("messages", "raw", "/raw")
+	assert.True(t, ok)
+
+	_, err := fn(context.Background(), &common.TopicEvent{ID: "1", DataBase64: "aGVsbG8="})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, handled)
+
+	_, err = fn(context.Background(), &common.TopicEvent{ID: "1", RawData: []byte("hello")})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, handled)
+
+	_, err = fn(context.Background(), &common.TopicEvent{ID: "1", DataBase64: "not base64"})
+	assert.Error(t, err)
+}
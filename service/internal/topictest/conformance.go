@@ -0,0 +1,213 @@
+// Package topictest is the conformance test suite of the topic events, which the HTTP and gRPC services both run, so
+// that handlers are handed the same events whatever the transport.
+package topictest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/dapr/go-sdk/service/common"
+	"github.com/dapr/go-sdk/service/internal/contenttype"
+)
+
+// CloudEvent is an event as Dapr delivers it, before it's converted for the transport.
+type CloudEvent struct {
+	ID              string
+	Type            string
+	Source          string
+	DataContentType string
+	// Data is the value of the data field, DataBase64 is the data_base64 field of binary data.
+	Data       interface{}
+	DataBase64 string
+}
+
+// JSON returns the event as Dapr sends it to HTTP services.
+func (ce *CloudEvent) JSON(pubsubName, topic string) []byte {
+	envelope := map[string]interface{}{
+		"id":              ce.ID,
+		"specversion":     "1.0",
+		"type":            ce.Type,
+		"source":          ce.Source,
+		"datacontenttype": ce.DataContentType,
+		"topic":           topic,
+		"pubsubname":      pubsubName,
+	}
+	if ce.DataBase64 != "" {
+		envelope["data_base64"] = ce.DataBase64
+	} else {
+		envelope["data"] = ce.Data
+	}
+	data, _ := json.Marshal(envelope)
+	return data
+}
+
+// GRPCData returns the data of the event as Dapr sends it to gRPC services.
+func (ce *CloudEvent) GRPCData() []byte {
+	if ce.DataBase64 != "" {
+		data, _ := base64.StdEncoding.DecodeString(ce.DataBase64)
+		return data
+	}
+	if s, ok := ce.Data.(string); ok && !contenttype.IsJSON(ce.DataContentType) {
+		return []byte(s)
+	}
+	if contenttype.IsJSON(ce.DataContentType) {
+		data, _ := json.Marshal(ce.Data)
+		return data
+	}
+	return nil
+}
+
+// Transport is a service under test.
+type Transport interface {
+	AddTopicEventHandler(sub *common.Subscription, fn func(ctx context.Context, e *common.TopicEvent) (retry bool, err error)) error
+	// Deliver delivers @ce published to topic @topic of @pubsubName to the route @route of the service, which must
+	// handle it successfully.
+	Deliver(t *testing.T, pubsubName, topic, route string, ce *CloudEvent)
+}
+
+type order struct {
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+}
+
+// Run runs the conformance test suite against @transport.
+func Run(t *testing.T, transport Transport) {
+	var handled *common.TopicEvent
+	var decoded interface{}
+	protoData, _ := proto.Marshal(wrapperspb.String("hello"))
+	binary := []byte{0, 1, 2}
+
+	tests := []struct {
+		name          string
+		event         *CloudEvent
+		expectedData  interface{}
+		expectedRaw   string
+		expectedBase  string
+		typedHandler  interface{}
+		expectedTyped interface{}
+	}{
+		{
+			name:         "json object",
+			event:        &CloudEvent{DataContentType: "application/json", Data: map[string]interface{}{"message": "hello", "count": 1}},
+			expectedData: map[string]interface{}{"message": "hello", "count": float64(1)},
+			expectedRaw:  `{"count":1,"message":"hello"}`,
+			typedHandler: func(ctx context.Context, e *common.TopicEvent, v *order) (bool, error) {
+				decoded = v
+				return false, nil
+			},
+			expectedTyped: &order{Message: "hello", Count: 1},
+		},
+		{
+			name:         "json string",
+			event:        &CloudEvent{DataContentType: "application/json", Data: "hello"},
+			expectedData: "hello",
+			expectedRaw:  `"hello"`,
+			typedHandler: func(ctx context.Context, e *common.TopicEvent, v string) (bool, error) {
+				decoded = v
+				return false, nil
+			},
+			expectedTyped: "hello",
+		},
+		{
+			name:         "cloudevents json",
+			event:        &CloudEvent{DataContentType: "application/cloudevents+json", Data: map[string]interface{}{"message": "hello"}},
+			expectedData: map[string]interface{}{"message": "hello"},
+			expectedRaw:  `{"message":"hello"}`,
+			typedHandler: func(ctx context.Context, e *common.TopicEvent, v order) (bool, error) {
+				decoded = v
+				return false, nil
+			},
+			expectedTyped: order{Message: "hello"},
+		},
+		{
+			name:         "text",
+			event:        &CloudEvent{DataContentType: "text/plain", Data: "hello"},
+			expectedData: "hello",
+			expectedRaw:  "hello",
+			typedHandler: func(ctx context.Context, e *common.TopicEvent, v *string) (bool, error) {
+				decoded = *v
+				return false, nil
+			},
+			expectedTyped: "hello",
+		},
+		{
+			name:         "numeric text without content type",
+			event:        &CloudEvent{Data: "123"},
+			expectedData: "123",
+			expectedRaw:  "123",
+			typedHandler: func(ctx context.Context, e *common.TopicEvent, v string) (bool, error) {
+				decoded = v
+				return false, nil
+			},
+			expectedTyped: "123",
+		},
+		{
+			name:         "base64 binary",
+			event:        &CloudEvent{DataContentType: "application/octet-stream", DataBase64: base64.StdEncoding.EncodeToString(binary)},
+			expectedData: binary,
+			expectedRaw:  string(binary),
+			expectedBase: base64.StdEncoding.EncodeToString(binary),
+			typedHandler: func(ctx context.Context, e *common.TopicEvent, v []byte) (bool, error) {
+				decoded = v
+				return false, nil
+			},
+			expectedTyped: binary,
+		},
+		{
+			name:         "protobuf",
+			event:        &CloudEvent{DataContentType: "application/x-protobuf", DataBase64: base64.StdEncoding.EncodeToString(protoData)},
+			expectedData: protoData,
+			expectedRaw:  string(protoData),
+			expectedBase: base64.StdEncoding.EncodeToString(protoData),
+			typedHandler: func(ctx context.Context, e *common.TopicEvent, v *wrapperspb.StringValue) (bool, error) {
+				decoded = v.GetValue()
+				return false, nil
+			},
+			expectedTyped: "hello",
+		},
+	}
+
+	err := transport.AddTopicEventHandler(&common.Subscription{PubsubName: "messages", Topic: "events", Route: "/events"},
+		func(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
+			handled = e
+			return false, nil
+		})
+	assert.NoError(t, err)
+	for i, tt := range tests {
+		fn, err := common.NewTypedTopicEventHandler(tt.typedHandler)
+		assert.NoError(t, err)
+		err = transport.AddTopicEventHandler(&common.Subscription{PubsubName: "messages", Topic: fmt.Sprintf("typed%d", i), Route: fmt.Sprintf("/typed%d", i)}, fn)
+		assert.NoError(t, err)
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.event.ID, tt.event.Type, tt.event.Source = fmt.Sprintf("event-%d", i), "test.event", "test"
+			handled = nil
+			transport.Deliver(t, "messages", "events", "/events", tt.event)
+			assert.Equal(t, &common.TopicEvent{
+				ID:              tt.event.ID,
+				SpecVersion:     "1.0",
+				Type:            "test.event",
+				Source:          "test",
+				DataContentType: tt.event.DataContentType,
+				Data:            tt.expectedData,
+				RawData:         []byte(tt.expectedRaw),
+				DataBase64:      tt.expectedBase,
+				Topic:           "events",
+				PubsubName:      "messages",
+			}, handled)
+
+			decoded = nil
+			transport.Deliver(t, "messages", fmt.Sprintf("typed%d", i), fmt.Sprintf("/typed%d", i), tt.event)
+			assert.Equal(t, tt.expectedTyped, decoded)
+		})
+	}
+}